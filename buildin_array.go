@@ -3,6 +3,8 @@ package template
 import (
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
 	"github.com/kaptinlin/filter"
 )
@@ -23,6 +25,10 @@ func init() {
 		"sum":     sumFilter,
 		"average": averageFilter,
 		"map":     mapFilter,
+		"filter":  filterFilter,
+		"index":   indexFilter,
+		"list":    listFilter,
+		"batch":   batchFilter,
 	}
 
 	for name, filterFunc := range filtersToRegister {
@@ -104,3 +110,146 @@ func mapFilter(value interface{}, args ...string) (interface{}, error) {
 	key := args[0]
 	return filter.Map(value, key)
 }
+
+// filterFilter returns the elements of a slice whose field satisfies a comparison with the given value.
+// With two arguments the field must equal the value; with three, the middle argument is the operator.
+func filterFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: filter filter requires a field and a value argument", ErrInsufficientArgs)
+	}
+	field, operator, target := args[0], "==", args[1]
+	if len(args) > 2 {
+		operator, target = args[1], args[2]
+	}
+
+	items, err := toSlice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		fieldValue, ok := resolveField(item, field)
+		if !ok {
+			continue
+		}
+		matched, err := compareWithOperator(fieldValue, operator, target)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// compareWithOperator evaluates "left operator right" using compareValues.
+// Values that cannot be compared are never equal, and ordering them is an error.
+func compareWithOperator(left interface{}, operator string, right interface{}) (bool, error) {
+	cmp, err := compareValues(left, right)
+	switch operator {
+	case "==", "=":
+		return err == nil && cmp == 0, nil
+	case "!=":
+		return err != nil || cmp != 0, nil
+	case ">", ">=", "<", "<=":
+		if err != nil {
+			return false, err
+		}
+		switch operator {
+		case ">":
+			return cmp > 0, nil
+		case ">=":
+			return cmp >= 0, nil
+		case "<":
+			return cmp < 0, nil
+		default:
+			return cmp <= 0, nil
+		}
+	default:
+		return false, fmt.Errorf("%w: unknown comparison operator '%s'", ErrFilterArgsInvalid, operator)
+	}
+}
+
+// indexFilter returns the element of a slice at the given position, or the entry of a map or the field
+// of a struct with the given key. A negative position counts from the end of a slice.
+func indexFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: index filter requires an index or key argument", ErrInsufficientArgs)
+	}
+	key := args[0]
+	if position, err := strconv.Atoi(key); err == nil && position < 0 {
+		if items, err := toSlice(value); err == nil {
+			key = strconv.Itoa(len(items) + position)
+		}
+	}
+	element, ok := resolveKey(value, key)
+	if !ok {
+		return nil, fmt.Errorf("%w: index '%s' not found", ErrFilterArgsInvalid, args[0])
+	}
+	return element, nil
+}
+
+// listFilter renders a slice as text. Without arguments it uses the default [a, b, c] format.
+// The first argument replaces the ", " separator and drops the brackets; optional second and
+// third arguments set the opening and closing text.
+func listFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) == 0 {
+		return convertToString(value)
+	}
+	items, err := toSlice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		part, err := convertToString(item)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+
+	var opening, closing string
+	if len(args) > 1 {
+		opening = args[1]
+	}
+	if len(args) > 2 {
+		closing = args[2]
+	}
+	return opening + strings.Join(parts, args[0]) + closing, nil
+}
+
+// batchFilter splits a slice into chunks of the given size. An optional second argument fills
+// the last chunk up to the full size.
+func batchFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: batch filter requires a size argument", ErrInsufficientArgs)
+	}
+	size, err := strconv.Atoi(args[0])
+	if err != nil || size < 1 {
+		return nil, fmt.Errorf("%w: batch filter size must be a positive integer, got '%s'", ErrFilterArgsInvalid, args[0])
+	}
+	items, err := toSlice(value)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := make([][]interface{}, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		batch := append(make([]interface{}, 0, size), items[start:end]...)
+		if len(args) > 1 {
+			for len(batch) < size {
+				batch = append(batch, args[1])
+			}
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
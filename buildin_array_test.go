@@ -2,8 +2,21 @@ package template
 
 import (
 	"testing"
+	"time"
 )
 
+type testProduct struct {
+	Name   string
+	Price  float64
+	Active bool `json:"active"`
+}
+
+var testProducts = []testProduct{
+	{Name: "Laptop", Price: 999.99, Active: true},
+	{Name: "Mouse", Price: 19.5, Active: false},
+	{Name: "Monitor", Price: 249, Active: true},
+}
+
 func TestArrayFilters(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -17,12 +30,78 @@ func TestArrayFilters(t *testing.T) {
 			context:  map[string]interface{}{"value": []int{1, 2, 2, 3}},
 			expected: "1,2,3",
 		},
+		{
+			name:     "IndexFilterWithLoopIndex",
+			template: "{{ items | index:loop.index }}",
+			context:  map[string]interface{}{"items": []string{"a", "b", "c"}, "loop": map[string]interface{}{"index": 1}},
+			expected: "b",
+		},
+		{
+			name:     "IndexFilterWithVariable",
+			template: "{{ items | index:position | upper }}",
+			context:  map[string]interface{}{"items": []string{"a", "b", "c"}, "position": 2},
+			expected: "C",
+		},
+		{
+			name:     "IndexFilterNegative",
+			template: "{{ items | index:-1 }}",
+			context:  map[string]interface{}{"items": []int{10, 20, 30}},
+			expected: "30",
+		},
+		{
+			name:     "IndexFilterMapKey",
+			template: "{{ revenue | index:year }}",
+			context:  map[string]interface{}{"revenue": map[string]interface{}{"a.b": 5, "2023": 7}, "year": "2023"},
+			expected: "7",
+		},
 		{
 			name:     "JoinFilter",
 			template: "{{ value | join:'-' }}",
 			context:  map[string]interface{}{"value": []string{"one", "two", "three"}},
 			expected: "one-two-three",
 		},
+		{
+			name:     "ListFilterDefault",
+			template: "{{ value | list }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3}},
+			expected: "[1, 2, 3]",
+		},
+		{
+			name:     "ListFilterSeparator",
+			template: "{{ value | list:'; ' }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3}},
+			expected: "1; 2; 3",
+		},
+		{
+			name:     "ListFilterBrackets",
+			template: "{{ value | list:' | ','(',')' }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3}},
+			expected: "(1 | 2 | 3)",
+		},
+		{
+			name:     "BatchFilterCount",
+			template: "{{ value | batch:3 | size }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3, 4, 5, 6, 7}},
+			expected: "3",
+		},
+		{
+			name:     "BatchFilterLastChunk",
+			template: "{{ value | batch:3 | last | join:',' }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3, 4, 5, 6, 7}},
+			expected: "7",
+		},
+		{
+			name:     "BatchFilterPadded",
+			template: "{{ value | batch:3,'-' | last | join:',' }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3, 4, 5, 6, 7}},
+			expected: "7,-,-",
+		},
+		{
+			name:     "BatchFilterFullChunk",
+			template: "{{ value | batch:3,'-' | first | join:',' }}",
+			context:  map[string]interface{}{"value": []int{1, 2, 3, 4, 5, 6, 7}},
+			expected: "1,2,3",
+		},
 		{
 			name:     "FirstFilter",
 			template: "{{ value | first }}",
@@ -94,6 +173,46 @@ func TestArrayFilters(t *testing.T) {
 			},
 			expected: "John, Jane",
 		},
+		{
+			name:     "FilterFilterByBoolField",
+			template: `{{ products | filter:"active","true" | size }}`,
+			context:  map[string]interface{}{"products": testProducts},
+			expected: "2",
+		},
+		{
+			name:     "FilterFilterByNumericThreshold",
+			template: `{{ products | filter:"Price",">=",249 | first | extract:"Name" }}`,
+			context:  map[string]interface{}{"products": []map[string]interface{}{{"Name": "Mouse", "Price": 19.5}, {"Name": "Monitor", "Price": 249}}},
+			expected: "Monitor",
+		},
+		{
+			name:     "FilterFilterStructByNumericThreshold",
+			template: `{{ products | filter:"Price","<",250 | size }}`,
+			context:  map[string]interface{}{"products": testProducts},
+			expected: "2",
+		},
+		{
+			name:     "FilterFilterNotEqual",
+			template: `{{ products | filter:"name","!=","Jane" | map:"name" | join:", " }}`,
+			context: map[string]interface{}{
+				"products": []map[string]interface{}{
+					{"name": "John"},
+					{"name": "Jane"},
+				},
+			},
+			expected: "John",
+		},
+		{
+			name:     "FilterFilterByTime",
+			template: `{{ events | filter:"at",">=","2024-03-01T12:00:00Z" | map:"name" | join:", " }}`,
+			context: map[string]interface{}{
+				"events": []map[string]interface{}{
+					{"name": "standup", "at": time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+					{"name": "review", "at": time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)},
+				},
+			},
+			expected: "review",
+		},
 	}
 
 	for _, tc := range cases {
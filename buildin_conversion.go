@@ -0,0 +1,109 @@
+package template
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	// Register type conversion filters
+	filtersToRegister := map[string]FilterFunc{
+		"int":    intFilter,
+		"float":  floatFilter,
+		"string": stringFilter,
+		"bool":   boolFilter,
+	}
+
+	for name, filterFunc := range filtersToRegister {
+		if err := RegisterFilter(name, filterFunc); err != nil {
+			log.Printf("Error registering filter %s: %v", name, err)
+		}
+	}
+}
+
+// intFilter converts a number, numeric string, or bool to an int, truncating any fraction.
+// An optional argument is returned instead of an error when the input cannot be converted.
+func intFilter(value interface{}, args ...string) (interface{}, error) {
+	number, err := toConvertibleNumber(value)
+	if err == nil {
+		switch n := number.(type) {
+		case int:
+			return n, nil
+		case float64:
+			// float64(math.MaxInt) rounds up to 2^63, so the upper bound is exclusive. The range check also
+			// rejects NaN and infinities.
+			if n >= math.MinInt && n < -float64(math.MinInt) {
+				return int(n), nil
+			}
+		}
+		err = fmt.Errorf("%w: cannot convert %v to an integer", ErrFilterInputNotNumeric, value)
+	}
+	if len(args) > 0 {
+		return intFilter(args[0])
+	}
+	return nil, err
+}
+
+// floatFilter converts a number, numeric string, or bool to a float64.
+// An optional argument is returned instead of an error when the input cannot be converted.
+func floatFilter(value interface{}, args ...string) (interface{}, error) {
+	number, err := toConvertibleNumber(value)
+	if err == nil {
+		return toFloat64(number)
+	}
+	if len(args) > 0 {
+		return floatFilter(args[0])
+	}
+	return nil, err
+}
+
+// toConvertibleNumber normalizes the input of int and float, treating true as 1 and false as 0.
+func toConvertibleNumber(value interface{}) (interface{}, error) {
+	if b, ok := dereferenceIfNeeded(value).(bool); ok {
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	return toNumber(value)
+}
+
+// stringFilter converts a value to the text a {{ }} tag would render for it.
+func stringFilter(value interface{}, args ...string) (interface{}, error) {
+	return convertToString(value)
+}
+
+// boolFilter converts a value to a bool. Strings accepted by strconv.ParseBool are parsed and the
+// empty string is false; numbers are true when they are not zero, and nil is false.
+// An optional argument is returned instead of an error when the input cannot be converted.
+func boolFilter(value interface{}, args ...string) (interface{}, error) {
+	switch v := dereferenceIfNeeded(value).(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case string:
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" {
+			return false, nil
+		}
+		if b, err := strconv.ParseBool(trimmed); err == nil {
+			return b, nil
+		}
+	default:
+		if isNumber(v) {
+			n, err := toFloat64(v)
+			if err != nil {
+				return nil, err
+			}
+			return n != 0, nil
+		}
+	}
+	if len(args) > 0 {
+		return boolFilter(args[0])
+	}
+	return nil, fmt.Errorf("%w: cannot convert %v to a bool", ErrFilterInputInvalid, value)
+}
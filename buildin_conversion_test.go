@@ -0,0 +1,69 @@
+package template
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConversionFilters(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		context  map[string]interface{}
+		expected string
+	}{
+		{"IntFromString", "{{ '42' | int | plus:1 }}", nil, "43"},
+		{"IntFromFloat", "{{ value | int }}", map[string]interface{}{"value": 3.9}, "3"},
+		{"IntFromBool", "{{ value | int }}", map[string]interface{}{"value": true}, "1"},
+		{"IntFallback", "{{ 'abc' | int:0 }}", nil, "0"},
+		{"FloatFromInt", "{{ 3 | float | divide:2 }}", nil, "1.5"},
+		{"FloatFromString", "{{ ' 2.5 ' | float }}", nil, "2.5"},
+		{"FloatFallback", "{{ 'n/a' | float:'0.5' }}", nil, "0.5"},
+		{"StringFromInt", "{{ value | string | append:'!' }}", map[string]interface{}{"value": 7}, "7!"},
+		{"StringFromSlice", "{{ value | string }}", map[string]interface{}{"value": []int{1, 2}}, "[1, 2]"},
+		{"BoolFromString", "{{ 'true' | bool }}", nil, "true"},
+		{"BoolFromZero", "{{ value | bool }}", map[string]interface{}{"value": 0}, "false"},
+		{"BoolFromNumber", "{{ value | bool }}", map[string]interface{}{"value": 2.5}, "true"},
+		{"BoolFromEmptyString", "{{ '' | bool }}", nil, "false"},
+		{"BoolFallback", "{{ 'maybe' | bool:'false' }}", nil, "false"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, tc.context)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestConversionFilterErrors(t *testing.T) {
+	if _, err := intFilter("abc"); !errors.Is(err, ErrFilterInputNotNumeric) {
+		t.Errorf("Expected ErrFilterInputNotNumeric from int, got %v", err)
+	}
+	if _, err := intFilter(1e300); !errors.Is(err, ErrFilterInputNotNumeric) {
+		t.Errorf("Expected ErrFilterInputNotNumeric from int of an out-of-range float, got %v", err)
+	}
+	if _, err := intFilter("9223372036854775808"); !errors.Is(err, ErrFilterInputNotNumeric) {
+		t.Errorf("Expected ErrFilterInputNotNumeric from int of 2^63, got %v", err)
+	}
+	if _, err := intFilter(math.Inf(-1)); !errors.Is(err, ErrFilterInputNotNumeric) {
+		t.Errorf("Expected ErrFilterInputNotNumeric from int of an infinity, got %v", err)
+	}
+	if _, err := floatFilter("abc"); !errors.Is(err, ErrFilterInputNotNumeric) {
+		t.Errorf("Expected ErrFilterInputNotNumeric from float, got %v", err)
+	}
+	if _, err := boolFilter("maybe"); !errors.Is(err, ErrFilterInputInvalid) {
+		t.Errorf("Expected ErrFilterInputInvalid from bool, got %v", err)
+	}
+
+	result, err := Render("{{ 'abc' | int }}", nil)
+	if err == nil {
+		t.Errorf("Expected an error for 'abc' | int, got output '%s'", result)
+	}
+}
@@ -9,7 +9,6 @@ import (
 func init() {
 	// Register all date filters
 	filtersToRegister := map[string]FilterFunc{
-		"date":       dateFilter,
 		"day":        dayFilter,
 		"month":      monthFilter,
 		"month_full": monthFullFilter,
@@ -24,13 +23,24 @@ func init() {
 			log.Printf("Error registering filter %s: %v", name, err)
 		}
 	}
+
+	// The date filter reads the template's locale for its default format.
+	if err := registerTemplateFilter("date", dateFilter); err != nil {
+		log.Printf("Error registering filter %s: %v", "date", err)
+	}
 }
 
-// dateFilter formats a timestamp into a specified format.
-func dateFilter(value interface{}, args ...string) (interface{}, error) {
+// dateFilter formats a timestamp into a specified format. Without a format, a template whose locale has
+// a registered provider other than the built-in en-US one formats the date in that locale's style.
+func dateFilter(tpl *Template, _ Context, value interface{}, args ...interface{}) (interface{}, error) {
 	format := ""
 	if len(args) > 0 {
-		format = args[0]
+		format = toString(args[0])
+	}
+	if provider := tpl.Locale(); format == "" && provider != LocaleProvider(defaultLocaleProvider) {
+		if t, ok := toTime(dereferenceIfNeeded(value)); ok {
+			return provider.FormatDate(t), nil
+		}
 	}
 	return filter.Date(value, format)
 }
@@ -0,0 +1,163 @@
+package template
+
+import (
+	"crypto/md5"  //nolint: gosec // Used for checksums, not for security.
+	"crypto/sha1" //nolint: gosec // Used for checksums, not for security.
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	// Register all encoding filters
+	filtersToRegister := map[string]FilterFunc{
+		"urlencode":    urlencodeFilter,
+		"urldecode":    urldecodeFilter,
+		"base64encode": base64encodeFilter,
+		"base64decode": base64decodeFilter,
+		"md5":          md5Filter,
+		"sha1":         sha1Filter,
+		"sha256":       sha256Filter,
+		"sha512":       sha512Filter,
+		"hash":         hashFilter,
+		"attr":         attrFilter,
+		"escape_once":  escapeOnceFilter,
+	}
+
+	for name, filterFunc := range filtersToRegister {
+		if err := RegisterFilter(name, filterFunc); err != nil {
+			log.Printf("Error registering filter %s: %v", name, err)
+		}
+	}
+}
+
+// attrReplacer escapes the characters that are unsafe inside a quoted HTML or XML attribute value.
+var attrReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	`"`, "&quot;",
+	"'", "&#39;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// htmlEntity matches a named or numeric HTML character reference such as &amp;, &#39;, or &#x27;.
+var htmlEntity = regexp.MustCompile(`&(?:[a-zA-Z][a-zA-Z0-9]*|#[0-9]+|#[xX][0-9a-fA-F]+);`)
+
+// urlencodeFilter escapes a string for use in a URL query, or in a URL path segment when given "path".
+func urlencodeFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) > 0 && args[0] == "path" {
+		return url.PathEscape(toString(value)), nil
+	}
+	return url.QueryEscape(toString(value)), nil
+}
+
+// urldecodeFilter reverses urlencode, decoding a URL query string, or a path segment when given "path".
+func urldecodeFilter(value interface{}, args ...string) (interface{}, error) {
+	var decoded string
+	var err error
+	if len(args) > 0 && args[0] == "path" {
+		decoded, err = url.PathUnescape(toString(value))
+	} else {
+		decoded, err = url.QueryUnescape(toString(value))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFilterInputInvalid, err)
+	}
+	return decoded, nil
+}
+
+// base64Encoding selects URL-safe encoding when given "url" and standard encoding otherwise.
+func base64Encoding(args []string) *base64.Encoding {
+	if len(args) > 0 && args[0] == "url" {
+		return base64.URLEncoding
+	}
+	return base64.StdEncoding
+}
+
+// base64encodeFilter encodes a string as base64, using the URL-safe alphabet when given "url".
+func base64encodeFilter(value interface{}, args ...string) (interface{}, error) {
+	return base64Encoding(args).EncodeToString([]byte(toString(value))), nil
+}
+
+// base64decodeFilter decodes a base64 string, using the URL-safe alphabet when given "url".
+func base64decodeFilter(value interface{}, args ...string) (interface{}, error) {
+	decoded, err := base64Encoding(args).DecodeString(toString(value))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFilterInputInvalid, err)
+	}
+	return string(decoded), nil
+}
+
+// hashAlgorithms maps algorithm names accepted by the hash filter to their constructors.
+var hashAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// hexDigest returns the hex-encoded digest of the stringified value using the named algorithm.
+func hexDigest(algorithm string, value interface{}) (string, error) {
+	newHash, ok := hashAlgorithms[algorithm]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown hash algorithm '%s'", ErrFilterArgsInvalid, algorithm)
+	}
+	h := newHash()
+	h.Write([]byte(toString(value)))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// md5Filter returns the hex-encoded MD5 digest of a string.
+func md5Filter(value interface{}, args ...string) (interface{}, error) {
+	return hexDigest("md5", value)
+}
+
+// sha1Filter returns the hex-encoded SHA-1 digest of a string.
+func sha1Filter(value interface{}, args ...string) (interface{}, error) {
+	return hexDigest("sha1", value)
+}
+
+// sha256Filter returns the hex-encoded SHA-256 digest of a string.
+func sha256Filter(value interface{}, args ...string) (interface{}, error) {
+	return hexDigest("sha256", value)
+}
+
+// sha512Filter returns the hex-encoded SHA-512 digest of a string.
+func sha512Filter(value interface{}, args ...string) (interface{}, error) {
+	return hexDigest("sha512", value)
+}
+
+// hashFilter returns the hex-encoded digest of a string using the algorithm named by its argument.
+func hashFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: hash filter requires an algorithm argument", ErrInsufficientArgs)
+	}
+	return hexDigest(args[0], value)
+}
+
+// attrFilter escapes a value for use inside a single- or double-quoted HTML or XML attribute.
+func attrFilter(value interface{}, args ...string) (interface{}, error) {
+	return attrReplacer.Replace(toString(value)), nil
+}
+
+// escapeOnceFilter escapes a value like attr but leaves existing character references intact,
+// so text that is already escaped is not escaped a second time.
+func escapeOnceFilter(value interface{}, args ...string) (interface{}, error) {
+	str := toString(value)
+	var builder strings.Builder
+	last := 0
+	for _, loc := range htmlEntity.FindAllStringIndex(str, -1) {
+		builder.WriteString(attrReplacer.Replace(str[last:loc[0]]))
+		builder.WriteString(str[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	builder.WriteString(attrReplacer.Replace(str[last:]))
+	return builder.String(), nil
+}
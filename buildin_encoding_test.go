@@ -0,0 +1,163 @@
+package template
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEncodingFilters(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		context  map[string]interface{}
+		expected string
+	}{
+		{
+			name:     "UrlencodeFilter",
+			template: "{{ query | urlencode }}",
+			context:  map[string]interface{}{"query": "go templates & filters?"},
+			expected: "go+templates+%26+filters%3F",
+		},
+		{
+			name:     "AttrFilter",
+			template: `<a title="{{ title | attr }}">`,
+			context:  map[string]interface{}{"title": `Tom's "best" <b>deals</b> & more`},
+			expected: `<a title="Tom&#39;s &quot;best&quot; &lt;b&gt;deals&lt;/b&gt; &amp; more">`,
+		},
+		{
+			name:     "AttrFilterAlreadyEscaped",
+			template: "{{ title | attr }}",
+			context:  map[string]interface{}{"title": "&amp;"},
+			expected: "&amp;amp;",
+		},
+		{
+			name:     "EscapeOnceFilterRawInput",
+			template: "{{ title | escape_once }}",
+			context:  map[string]interface{}{"title": `Tom's <b>deals</b> & more`},
+			expected: "Tom&#39;s &lt;b&gt;deals&lt;/b&gt; &amp; more",
+		},
+		{
+			name:     "EscapeOnceFilterAlreadyEscaped",
+			template: "{{ title | escape_once }}",
+			context:  map[string]interface{}{"title": "Fish &amp; Chips &lt;3 &#39;n&#x27; more"},
+			expected: "Fish &amp; Chips &lt;3 &#39;n&#x27; more",
+		},
+		{
+			name:     "EscapeOnceFilterMixed",
+			template: "{{ title | escape_once | escape_once }}",
+			context:  map[string]interface{}{"title": "AT&T &amp; <Co>"},
+			expected: "AT&amp;T &amp; &lt;Co&gt;",
+		},
+		{
+			name:     "UrlencodeFilterPath",
+			template: "{{ segment | urlencode:'path' }}",
+			context:  map[string]interface{}{"segment": "a b/c"},
+			expected: "a%20b%2Fc",
+		},
+		{
+			name:     "UrlencodeFilterNonString",
+			template: "{{ page | urlencode }}",
+			context:  map[string]interface{}{"page": 42},
+			expected: "42",
+		},
+		{
+			name:     "UrldecodeFilterRoundTrip",
+			template: "{{ query | urlencode | urldecode }}",
+			context:  map[string]interface{}{"query": "name=Jane Doe&city=São Paulo"},
+			expected: "name=Jane Doe&city=São Paulo",
+		},
+		{
+			name:     "Base64encodeFilter",
+			template: "{{ data | base64encode }}",
+			context:  map[string]interface{}{"data": "hello world"},
+			expected: "aGVsbG8gd29ybGQ=",
+		},
+		{
+			name:     "Base64decodeFilter",
+			template: "{{ token | base64decode }}",
+			context:  map[string]interface{}{"token": "aGVsbG8gd29ybGQ="},
+			expected: "hello world",
+		},
+		{
+			name:     "Base64FilterRoundTrip",
+			template: "{{ data | base64encode | base64decode }}",
+			context:  map[string]interface{}{"data": "Zoë <3 templates"},
+			expected: "Zoë <3 templates",
+		},
+		{
+			name:     "Base64encodeFilterURLSafe",
+			template: "{{ data | base64encode:'url' }}",
+			context:  map[string]interface{}{"data": "subjects?_d"},
+			expected: "c3ViamVjdHM_X2Q=",
+		},
+		{
+			name:     "Base64decodeFilterURLSafe",
+			template: "{{ data | base64decode:'url' }}",
+			context:  map[string]interface{}{"data": "c3ViamVjdHM_X2Q="},
+			expected: "subjects?_d",
+		},
+		{
+			name:     "Md5Filter",
+			template: "{{ content | md5 }}",
+			context:  map[string]interface{}{"content": "hello"},
+			expected: "5d41402abc4b2a76b9719d911017c592",
+		},
+		{
+			name:     "Sha256Filter",
+			template: "{{ email | sha256 }}",
+			context:  map[string]interface{}{"email": "jane@example.com"},
+			expected: "8c87b489ce35cf2e2f39f80e282cb2e804932a56a213983eeeb428407d43b52d",
+		},
+		{
+			name:     "HashFilterSha1",
+			template: "{{ content | hash:'sha1' }}",
+			context:  map[string]interface{}{"content": "hello"},
+			expected: "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Parse the template
+			tpl, err := Parse(tc.template)
+			if err != nil {
+				t.Fatalf("Failed to parse template: %v", err)
+			}
+
+			// Create a context and add variables
+			context := NewContext()
+			for k, v := range tc.context {
+				context.Set(k, v)
+			}
+
+			// Execute the template
+			output, err := Execute(tpl, context)
+			if err != nil {
+				t.Fatalf("Failed to execute template: %v", err)
+			}
+
+			// Verify the output matches the expected result
+			if output != tc.expected {
+				t.Errorf("Expected '%s', got '%s' for test case '%s'", tc.expected, output, tc.name)
+			}
+		})
+	}
+}
+
+func TestDecodingFiltersInvalidInput(t *testing.T) {
+	if _, err := urldecodeFilter("%zz"); !errors.Is(err, ErrFilterInputInvalid) {
+		t.Errorf("Expected ErrFilterInputInvalid from urldecode, got %v", err)
+	}
+	if _, err := base64decodeFilter("not base64!"); !errors.Is(err, ErrFilterInputInvalid) {
+		t.Errorf("Expected ErrFilterInputInvalid from base64decode, got %v", err)
+	}
+	if _, err := base64decodeFilter("c3ViamVjdHM_X2Q="); !errors.Is(err, ErrFilterInputInvalid) {
+		t.Errorf("Expected ErrFilterInputInvalid from base64decode with URL-safe input, got %v", err)
+	}
+}
+
+func TestHashFilterUnknownAlgorithm(t *testing.T) {
+	if _, err := hashFilter("hello", "crc64"); !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid, got %v", err)
+	}
+}
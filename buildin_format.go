@@ -1,15 +1,25 @@
 package template
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
+	"unicode/utf8"
 )
 
 func init() {
 	// Register all format filters
 	filtersToRegister := map[string]FilterFunc{
-		"json": jsonFilter,
+		"json":     jsonFilter,
+		"tojson":   toJSONFilter,
+		"jsonstr":  jsonStringFilter,
+		"dump":     dumpFilter,
+		"debug":    dumpFilter,
+		"csvquote": csvQuoteFilter,
+		"csvrow":   csvRowFilter,
 	}
 
 	for name, filterFunc := range filtersToRegister {
@@ -27,3 +37,84 @@ func jsonFilter(input interface{}, args ...string) (interface{}, error) {
 	}
 	return string(jsonBytes), nil
 }
+
+// toJSONFilter serializes the input as JSON without HTML escaping. The "script" argument escapes <, >, and &
+// as \u003c, \u003e, and \u0026, so the output can be embedded in a <script> element.
+// U+2028 and U+2029 are always escaped.
+func toJSONFilter(input interface{}, args ...string) (interface{}, error) {
+	escapeHTML := false
+	if len(args) > 0 {
+		if args[0] != "script" {
+			return nil, fmt.Errorf("%w: tojson filter accepts only 'script', got '%s'", ErrFilterArgsInvalid, args[0])
+		}
+		escapeHTML = true
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(escapeHTML)
+	if err := encoder.Encode(input); err != nil {
+		return nil, fmt.Errorf("error marshaling to JSON: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// jsonStringFilter escapes the input as the body of a JSON string, without the surrounding quotes.
+// Quotes, backslashes, and control characters are escaped; HTML characters are left as they are.
+func jsonStringFilter(input interface{}, args ...string) (interface{}, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(toString(input)); err != nil {
+		return nil, fmt.Errorf("error marshaling to JSON: %w", err)
+	}
+	encoded := strings.TrimSuffix(buf.String(), "\n")
+	return encoded[1 : len(encoded)-1], nil
+}
+
+// dumpFilter describes the input with its Go type and Go-syntax representation, for diagnosing data shape issues.
+func dumpFilter(input interface{}, args ...string) (interface{}, error) {
+	typeName := fmt.Sprintf("%T", input)
+	repr := fmt.Sprintf("%#v", input)
+	if !strings.Contains(repr, typeName) {
+		repr = fmt.Sprintf("%s(%s)", typeName, repr)
+	}
+	return repr, nil
+}
+
+// csvQuoteFilter wraps the input in double quotes and doubles any embedded quotes, as described in RFC 4180.
+func csvQuoteFilter(input interface{}, args ...string) (interface{}, error) {
+	return `"` + strings.ReplaceAll(toString(input), `"`, `""`) + `"`, nil
+}
+
+// csvRowFilter joins a slice into a single CSV line, quoting fields only where RFC 4180 requires it.
+// An optional argument sets a single-character delimiter other than a comma.
+func csvRowFilter(input interface{}, args ...string) (interface{}, error) {
+	items, err := toSlice(input)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, len(items))
+	for i, item := range items {
+		fields[i] = toString(item)
+	}
+
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if len(args) > 0 {
+		delimiter, size := utf8.DecodeRuneInString(args[0])
+		if size == 0 || size != len(args[0]) {
+			return nil, fmt.Errorf("%w: csvrow delimiter must be a single character, got '%s'", ErrFilterArgsInvalid, args[0])
+		}
+		writer.Comma = delimiter
+	}
+	if err := writer.Write(fields); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFilterArgsInvalid, err)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
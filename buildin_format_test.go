@@ -1,6 +1,7 @@
 package template
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -35,3 +36,130 @@ func TestJsonFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestToJSONFilter(t *testing.T) {
+	name := "Jane"
+	cases := []struct {
+		name     string
+		template string
+		context  Context
+		expected string
+	}{
+		{"MixedSlice", `{{ items|tojson }}`, Context{"items": []interface{}{1, 2.5, "a", true, nil, &name}}, `[1,2.5,"a",true,null,"Jane"]`},
+		{"Map", `{{ user|tojson }}`, Context{"user": map[string]interface{}{"name": "Jane", "age": 29}}, `{"age":29,"name":"Jane"}`},
+		{"Unescaped", `{{ html|tojson }}`, Context{"html": "<b>Tom & Jerry</b>"}, `"<b>Tom & Jerry</b>"`},
+		{"Script", `{{ html|tojson:"script" }}`, Context{"html": "</script><b>&\u2028"}, `"\u003c/script\u003e\u003cb\u003e\u0026\u2028"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, tc.context)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+
+	if _, err := toJSONFilter("x", "html"); !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid for an unknown mode, got %v", err)
+	}
+}
+
+func TestJSONStringFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"Quotes", `say "hi"`, `say \"hi\"`},
+		{"Backslash", `C:\temp`, `C:\\temp`},
+		{"Newline", "line1\nline2\r\n", `line1\nline2\r\n`},
+		{"Tab", "a\tb", `a\tb`},
+		{"ControlCharacter", "bell\x07", `bell\u0007`},
+		{"HTMLKept", "<b>&</b>", "<b>&</b>"},
+		{"Number", 42, "42"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := jsonStringFilter(tc.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if output != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, output)
+			}
+		})
+	}
+
+	result, err := Render(`{"bio": "{{ bio|jsonstr }}"}`, Context{"bio": "Likes \"Go\"\n"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != `{"bio": "Likes \"Go\"\n"}` {
+		t.Errorf("Expected valid JSON, got '%s'", result)
+	}
+}
+
+type dumpTestUser struct {
+	Name string
+	Age  int
+}
+
+func TestDumpFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{"Map", map[string]interface{}{"b": 2, "a": "x"}, `map[string]interface {}{"a":"x", "b":2}`},
+		{"Struct", dumpTestUser{Name: "Jane", Age: 29}, `template.dumpTestUser{Name:"Jane", Age:29}`},
+		{"Pointer", &dumpTestUser{Name: "Jane"}, `*template.dumpTestUser(&template.dumpTestUser{Name:"Jane", Age:0})`},
+		{"String", "hi", `string("hi")`},
+		{"Int", 42, `int(42)`},
+		{"Nil", nil, `<nil>`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := dumpFilter(tc.input)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if output != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, output)
+			}
+		})
+	}
+}
+
+func TestCSVFilters(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		context  Context
+		expected string
+	}{
+		{"QuoteComma", `{{ field|csvquote }}`, Context{"field": "Smith, John"}, `"Smith, John"`},
+		{"QuoteQuote", `{{ field|csvquote }}`, Context{"field": `say "hi"`}, `"say ""hi"""`},
+		{"QuoteNewline", `{{ field|csvquote }}`, Context{"field": "line1\nline2"}, "\"line1\nline2\""},
+		{"QuoteNumber", `{{ field|csvquote }}`, Context{"field": 42}, `"42"`},
+		{"Row", `{{ row|csvrow }}`, Context{"row": []interface{}{"Smith, John", `say "hi"`, 42, "plain"}}, `"Smith, John","say ""hi""",42,plain`},
+		{"RowDelimiter", `{{ row|csvrow:";" }}`, Context{"row": []string{"a;b", "c"}}, `"a;b";c`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, tc.context)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package template
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+func init() {
+	// Register all translation filters
+	filtersToRegister := map[string]templateFilterFunc{
+		"t":         translateFilter,
+		"translate": translateFilter,
+		"tplural":   pluralTranslateFilter,
+	}
+
+	for name, filterFunc := range filtersToRegister {
+		if err := registerTemplateFilter(name, filterFunc); err != nil {
+			log.Printf("Error registering filter %s: %v", name, err)
+		}
+	}
+}
+
+// maxMessageDepth limits how many translated messages may render one another, so a message that
+// translates its own key fails instead of recursing without end.
+const maxMessageDepth = 10
+
+// renderMessage renders a translated message as a template with the options of the executing template.
+func (t *Template) renderMessage(message string, ctx Context) (string, error) {
+	if t.messageDepth >= maxMessageDepth {
+		return "", fmt.Errorf("%w: more than %d levels", ErrTranslationDepth, maxMessageDepth)
+	}
+	parser := NewParser(
+		WithMissingBehavior(t.missingBehavior),
+		WithUnrenderableBehavior(t.unrenderableBehavior),
+		WithOnMissing(t.onMissing),
+		WithComplexRender(t.complexRender),
+		WithLocale(t.locale),
+	)
+	parser.SetUnrenderablePlaceholder(t.unrenderablePlaceholder)
+	parser.SetMethodCalls(!t.disableMethodCalls)
+	tpl, err := parser.Parse(message)
+	if err != nil {
+		return "", err
+	}
+	tpl.messageDepth = t.messageDepth + 1
+	return tpl.Execute(ctx)
+}
+
+// translateFilter looks up the input as a message key for the context's locale and renders the message
+// as a template with the same context and options, so messages can contain {{ }} variables. A key without
+// a message is returned unchanged.
+func translateFilter(t *Template, ctx Context, value interface{}, args ...interface{}) (interface{}, error) {
+	key := toString(value)
+	if translator == nil {
+		return key, nil
+	}
+	message, ok := translator.Translate(t.localeName(), key)
+	if !ok {
+		return key, nil
+	}
+	return t.renderMessage(message, ctx)
+}
+
+// pluralTranslateFilter selects a plural form for the count in the input. The argument is a message key
+// whose message lists the forms separated by "|" outside {{ }} tags. The locale's plural rule picks a
+// form, which is rendered with the context and the count as {{ count }}.
+func pluralTranslateFilter(t *Template, ctx Context, value interface{}, args ...interface{}) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: tplural filter requires a message key argument", ErrInsufficientArgs)
+	}
+
+	count, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	key := toString(args[0])
+	if translator == nil {
+		return key, nil
+	}
+	locale := t.localeName()
+	message, ok := translator.Translate(locale, key)
+	if !ok {
+		return key, nil
+	}
+
+	forms := splitPluralForms(message)
+	index := pluralRule(locale).PluralForm(count)
+	if index < 0 {
+		return nil, fmt.Errorf("%w: plural rule for '%s' returned form %d", ErrFilterArgsInvalid, locale, index)
+	}
+	if index >= len(forms) {
+		index = len(forms) - 1
+	}
+
+	formCtx := make(Context, len(ctx)+1)
+	for k, v := range ctx {
+		formCtx[k] = v
+	}
+	formCtx["count"] = value
+	return t.renderMessage(strings.TrimSpace(forms[index]), formCtx)
+}
+
+// splitPluralForms splits a message on the "|" characters that are not inside a {{ }} tag.
+func splitPluralForms(message string) []string {
+	var forms []string
+	inTag := false
+	start := 0
+	for i := 0; i < len(message); i++ {
+		switch {
+		case strings.HasPrefix(message[i:], "{{"):
+			inTag = true
+			i++
+		case strings.HasPrefix(message[i:], "}}"):
+			inTag = false
+			i++
+		case message[i] == '|' && !inTag:
+			forms = append(forms, message[start:i])
+			start = i + 1
+		}
+	}
+	return append(forms, message[start:])
+}
@@ -0,0 +1,127 @@
+package template
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTranslateFilter(t *testing.T) {
+	SetTranslator(MapTranslator{
+		"en-US": {"greeting": "Hello", "welcome": "Welcome back, {{ name }}!"},
+		"fr":    {"greeting": "Bonjour", "welcome": "Bon retour, {{ name|upper }} !"},
+	})
+	defer SetTranslator(nil)
+
+	cases := []struct {
+		name     string
+		template string
+		locale   string
+		expected string
+	}{
+		{"FoundKeyDefaultLocale", `{{ "greeting"|t }}`, "", "Hello"},
+		{"FoundKeyOtherLocale", `{{ "greeting"|t }}`, "fr", "Bonjour"},
+		{"MissingKeyFallsBack", `{{ "farewell"|t }}`, "fr", "farewell"},
+		{"InterpolatedMessage", `{{ "welcome"|t }}`, "en-US", "Welcome back, Jane!"},
+		{"InterpolatedMessageWithFilter", `{{ "welcome"|translate }}`, "fr", "Bon retour, JANE !"},
+		{"KeyFromVariable", `{{ key|t }}`, "fr", "Bonjour"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewContext()
+			ctx.Set("name", "Jane")
+			ctx.Set("key", "greeting")
+
+			result, err := Render(tc.template, ctx, WithLocale(tc.locale))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestTranslateFilterNestedMessages(t *testing.T) {
+	SetTranslator(MapTranslator{
+		"en-US": {
+			"loop":     `Again: {{ "loop"|t }}`,
+			"ping":     `ping {{ "pong"|t }}`,
+			"pong":     `pong {{ "ping"|t }}`,
+			"brand":    "Acme",
+			"greeting": `Welcome to {{ "brand"|t }}{{ missing }}`,
+		},
+	})
+	defer SetTranslator(nil)
+
+	for _, source := range []string{`{{ "loop"|t }}`, `{{ "ping"|t }}`} {
+		_, err := Render(source, NewContext())
+		if !errors.Is(err, ErrTranslationDepth) {
+			t.Errorf("%s: expected ErrTranslationDepth, got %v", source, err)
+		}
+	}
+
+	result, err := Render(`{{ "greeting"|t }}`, NewContext(), WithMissingBehavior(MissingEmpty))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "Welcome to Acme" {
+		t.Errorf("Expected the message to use the template's options, got '%s'", result)
+	}
+}
+
+func TestPluralTranslateFilter(t *testing.T) {
+	SetTranslator(MapTranslator{
+		"en-US": {"items": "{{ count }} item|{{ count }} items"},
+		"pl": {
+			"files":  "{{ count }} plik|{{ count }} pliki|{{ count }} plików",
+			"apples": "{{ count|floatformat:1 }} jabłko|{{ count }} jabłka",
+		},
+	})
+	RegisterPluralRule("pl", PluralRuleFunc(func(n float64) int {
+		switch {
+		case n == 1:
+			return 0
+		case int(n)%10 >= 2 && int(n)%10 <= 4 && (int(n)%100 < 10 || int(n)%100 >= 20):
+			return 1
+		default:
+			return 2
+		}
+	}))
+	defer SetTranslator(nil)
+
+	cases := []struct {
+		name     string
+		template string
+		locale   string
+		count    interface{}
+		expected string
+	}{
+		{"EnglishSingular", `{{ n|tplural:"items" }}`, "", 1, "1 item"},
+		{"EnglishPlural", `{{ n|tplural:"items" }}`, "", 3, "3 items"},
+		{"EnglishZero", `{{ n|tplural:"items" }}`, "", 0, "0 items"},
+		{"CustomRuleFirstForm", `{{ n|tplural:"files" }}`, "pl", 1, "1 plik"},
+		{"CustomRuleSecondForm", `{{ n|tplural:"files" }}`, "pl", 3, "3 pliki"},
+		{"CustomRuleThirdForm", `{{ n|tplural:"files" }}`, "pl", 5, "5 plików"},
+		{"CustomRuleThirdFormTeens", `{{ n|tplural:"files" }}`, "pl", 12, "12 plików"},
+		{"MissingFormUsesLast", `{{ n|tplural:"apples" }}`, "pl", 5, "5 jabłka"},
+		{"PipeInsideTag", `{{ n|tplural:"apples" }}`, "pl", 1, "1.0 jabłko"},
+		{"MissingKeyFallsBack", `{{ n|tplural:"unknown" }}`, "pl", 2, "unknown"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewContext()
+			ctx.Set("n", tc.count)
+
+			result, err := Render(tc.template, ctx, WithLocale(tc.locale))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
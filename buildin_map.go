@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 
 	"github.com/kaptinlin/filter"
 )
@@ -19,6 +20,22 @@ func init() {
 			log.Printf("Error registering filter %s: %v", name, err)
 		}
 	}
+
+	// Register get, which resolves paths the way the executing template resolves variables
+	if err := registerTemplateFilter("get", getFilter); err != nil {
+		log.Printf("Error registering filter %s: %v", "get", err)
+	}
+
+	// Register map filters that take maps as arguments
+	contextFiltersToRegister := map[string]ContextFilterFunc{
+		"merge": mergeFilter,
+	}
+
+	for name, filterFunc := range contextFiltersToRegister {
+		if err := RegisterContextFilter(name, filterFunc); err != nil {
+			log.Printf("Error registering filter %s: %v", name, err)
+		}
+	}
 }
 
 // extractFilter retrieves a nested value from a map, slice, or array using a dot-separated key path.
@@ -43,3 +60,75 @@ func extractFilter(value interface{}, args ...string) (interface{}, error) {
 	}
 	return result, nil
 }
+
+// getFilter looks up a key or dot-separated path on a map, slice, array, or struct, following the same
+// rules as a variable path: a Resolver receives the rest of the path, and methods are called unless the
+// template disables method calls. It returns an empty string when the path cannot be resolved.
+func getFilter(t *Template, _ Context, value interface{}, args ...interface{}) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: get filter requires a key argument", ErrInsufficientArgs)
+	}
+	result, found, err := resolvePath(value, toString(args[0]), !t.disableMethodCalls)
+	if err != nil && !errors.Is(err, ErrContextKeyNotFound) {
+		return nil, err
+	}
+	if !found {
+		return "", nil
+	}
+	return result, nil
+}
+
+// mergeFilter combines the input map with a map of defaults, with keys from the input taking precedence.
+// Passing "deep" as the second argument merges nested maps recursively instead of replacing them.
+func mergeFilter(_ Context, value interface{}, args ...interface{}) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: merge filter requires a map argument", ErrInsufficientArgs)
+	}
+	overrides, ok := toStringMap(value)
+	if !ok {
+		return nil, fmt.Errorf("%w: merge filter expects a map input, received %T", ErrFilterInputInvalid, value)
+	}
+	defaults, ok := toStringMap(args[0])
+	if !ok {
+		return nil, fmt.Errorf("%w: merge filter expects a map argument, received %T", ErrFilterArgsInvalid, args[0])
+	}
+	deep := len(args) > 1 && args[1] == "deep"
+	return mergeMaps(defaults, overrides, deep), nil
+}
+
+// mergeMaps returns a new map holding base overlaid with overrides, recursing into nested maps when deep is set.
+func mergeMaps(base, overrides map[string]interface{}, deep bool) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(overrides))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range overrides {
+		if deep {
+			baseNested, baseIsMap := toStringMap(result[k])
+			overrideNested, overrideIsMap := toStringMap(v)
+			if baseIsMap && overrideIsMap {
+				result[k] = mergeMaps(baseNested, overrideNested, deep)
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// toStringMap converts any map with string keys into a map[string]interface{}.
+func toStringMap(input interface{}) (map[string]interface{}, bool) {
+	if m, ok := input.(map[string]interface{}); ok {
+		return m, true
+	}
+	valRef := reflect.ValueOf(dereferenceIfNeeded(input))
+	if valRef.Kind() != reflect.Map || valRef.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	result := make(map[string]interface{}, valRef.Len())
+	iter := valRef.MapRange()
+	for iter.Next() {
+		result[iter.Key().String()] = iter.Value().Interface()
+	}
+	return result, true
+}
@@ -2,6 +2,7 @@ package template
 
 import (
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -63,6 +64,66 @@ func TestExtractFilter(t *testing.T) {
 			},
 			expected: "ErrContextIndexOutOfRange",
 		},
+		{
+			name:     "GetStaticKeyFromMap",
+			template: "{{ data | get:'user.name' }}",
+			context: map[string]interface{}{
+				"data": map[string]interface{}{
+					"user": map[string]interface{}{"name": "Alice"},
+				},
+			},
+			expected: "Alice",
+		},
+		{
+			name:     "GetVariableKeyFromMap",
+			template: "{{ data | get:field }}",
+			context: map[string]interface{}{
+				"data":  map[string]interface{}{"email": "alice@example.com"},
+				"field": "email",
+			},
+			expected: "alice@example.com",
+		},
+		{
+			name:     "GetStaticKeyFromStruct",
+			template: "{{ product | get:'Name' }}",
+			context: map[string]interface{}{
+				"product": testProduct{Name: "Laptop", Price: 999.99},
+			},
+			expected: "Laptop",
+		},
+		{
+			name:     "GetVariableKeyFromStruct",
+			template: "{{ product | get:field }}",
+			context: map[string]interface{}{
+				"product": &testProduct{Name: "Laptop", Active: true},
+				"field":   "active",
+			},
+			expected: "true",
+		},
+		{
+			name:     "GetMethodResult",
+			template: "{{ user | get:'FullName' }}",
+			context: map[string]interface{}{
+				"user": methodTestUser{First: "Ada", Last: "Lovelace"},
+			},
+			expected: "Ada Lovelace",
+		},
+		{
+			name:     "GetThroughResolver",
+			template: "{{ data | get:'store.items.count' | get:'path' }}",
+			context: map[string]interface{}{
+				"data": map[string]interface{}{"store": &pathEchoResolver{}},
+			},
+			expected: "items.count",
+		},
+		{
+			name:     "GetMissingKey",
+			template: "{{ data | get:'missing' | default:'n/a' }}",
+			context: map[string]interface{}{
+				"data": map[string]interface{}{"exists": "yes"},
+			},
+			expected: "n/a",
+		},
 	}
 
 	for _, tc := range cases {
@@ -102,3 +163,84 @@ func TestExtractFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeFilter(t *testing.T) {
+	defaults := map[string]interface{}{
+		"theme":  "light",
+		"layout": map[string]interface{}{"sidebar": true, "width": 960},
+	}
+	userConfig := map[string]interface{}{
+		"theme":  "dark",
+		"layout": map[string]interface{}{"width": 1200},
+	}
+
+	cases := []struct {
+		name     string
+		args     []interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "ShallowMerge",
+			args: []interface{}{defaults},
+			expected: map[string]interface{}{
+				"theme":  "dark",
+				"layout": map[string]interface{}{"width": 1200},
+			},
+		},
+		{
+			name: "DeepMerge",
+			args: []interface{}{defaults, "deep"},
+			expected: map[string]interface{}{
+				"theme":  "dark",
+				"layout": map[string]interface{}{"sidebar": true, "width": 1200},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := mergeFilter(nil, userConfig, tc.args...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+
+	if _, err := mergeFilter(nil, userConfig, "not a map"); !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid, got %v", err)
+	}
+}
+
+func TestMergeFilterWithVariableArgument(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("userConfig", map[string]interface{}{"theme": "dark"})
+	ctx.Set("defaultConfig", map[string]interface{}{"theme": "light", "lang": "en"})
+
+	output, err := Render("{{ userConfig | merge:defaultConfig | get:'theme' }}/{{ userConfig | merge:defaultConfig | get:'lang' }}", ctx)
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+	if output != "dark/en" {
+		t.Errorf("Expected 'dark/en', got '%s'", output)
+	}
+}
+
+func TestGetFilterWithoutMethodCalls(t *testing.T) {
+	tpl, err := Parse("{{ user | get:'FullName' | default:'hidden' }}", WithoutMethodCalls())
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	ctx := NewContext()
+	ctx.Set("user", methodTestUser{First: "Ada", Last: "Lovelace"})
+	output, err := tpl.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+	if output != "hidden" {
+		t.Errorf("Expected 'hidden', got '%s'", output)
+	}
+}
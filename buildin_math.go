@@ -3,6 +3,7 @@ package template
 import (
 	"fmt"
 	"log"
+	"math"
 
 	"github.com/kaptinlin/filter"
 )
@@ -21,6 +22,8 @@ func init() {
 		"times":   timesFilter,
 		"divide":  divideFilter,
 		"modulo":  moduloFilter,
+		"sign":    signFilter,
+		"clamp":   clampFilter,
 	}
 
 	for name, filterFunc := range filtersToRegister {
@@ -30,9 +33,35 @@ func init() {
 	}
 }
 
-// absFilter calculates the absolute value of a number.
+// absFilter calculates the absolute value of a number, keeping integers integral.
 func absFilter(value interface{}, args ...string) (interface{}, error) {
-	return filter.Abs(value)
+	number, err := toNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	if i, ok := number.(int); ok {
+		if i < 0 {
+			return -i, nil
+		}
+		return i, nil
+	}
+	return filter.Abs(number)
+}
+
+// signFilter returns -1, 0, or 1 depending on the sign of a number.
+func signFilter(value interface{}, args ...string) (interface{}, error) {
+	number, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case number < 0:
+		return -1, nil
+	case number > 0:
+		return 1, nil
+	default:
+		return 0, nil
+	}
 }
 
 // atLeastFilter ensures the number is at least as large as the minimum value provided.
@@ -72,30 +101,39 @@ func ceilFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Ceil(value)
 }
 
-// plusFilter adds two numbers.
+// plusFilter adds two numbers. The result stays an integer when both operands are integers.
 func plusFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 1 {
 		return nil, fmt.Errorf("%w: plus filter requires one argument", ErrInsufficientArgs)
 	}
 	addend := args[0]
+	if a, b, ok := integerOperands(value, addend); ok {
+		return a + b, nil
+	}
 	return filter.Plus(value, addend)
 }
 
-// minusFilter subtracts the second value from the first.
+// minusFilter subtracts the second value from the first. The result stays an integer when both operands are integers.
 func minusFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 1 {
 		return nil, fmt.Errorf("%w: minus filter requires one argument", ErrInsufficientArgs)
 	}
 	subtrahend := args[0]
+	if a, b, ok := integerOperands(value, subtrahend); ok {
+		return a - b, nil
+	}
 	return filter.Minus(value, subtrahend)
 }
 
-// timesFilter multiplies the first value by the second.
+// timesFilter multiplies the first value by the second. The result stays an integer when both operands are integers.
 func timesFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 1 {
 		return nil, fmt.Errorf("%w: times filter requires one argument", ErrInsufficientArgs)
 	}
 	multiplier := args[0]
+	if a, b, ok := integerOperands(value, multiplier); ok {
+		return a * b, nil
+	}
 	return filter.Times(value, multiplier)
 }
 
@@ -108,11 +146,65 @@ func divideFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Divide(value, divisor)
 }
 
-// moduloFilter returns the remainder of the division of the first value by the second.
+// moduloFilter returns the remainder of the division of the first value by the second, as an integer when both are integers.
 func moduloFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 1 {
 		return nil, fmt.Errorf("%w: modulo filter requires one argument", ErrInsufficientArgs)
 	}
 	modulus := args[0]
+	if a, b, ok := integerOperands(value, modulus); ok && b != 0 {
+		return a % b, nil
+	}
 	return filter.Modulo(value, modulus)
 }
+
+// integerOperands returns both operands as ints when both are integers or integer strings.
+func integerOperands(value interface{}, arg string) (int, int, bool) {
+	a, err := toNumber(value)
+	if err != nil {
+		return 0, 0, false
+	}
+	b, err := toNumber(arg)
+	if err != nil {
+		return 0, 0, false
+	}
+	ai, aIsInt := a.(int)
+	bi, bIsInt := b.(int)
+	return ai, bi, aIsInt && bIsInt
+}
+
+// clampFilter bounds a number into the inclusive range given by the min and max arguments.
+// The result stays an integer only when the value and both bounds are integers.
+func clampFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: clamp filter requires two arguments (min and max)", ErrInsufficientArgs)
+	}
+	number, err := toNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	lower, err := toNumber(args[0])
+	if err != nil {
+		return nil, err
+	}
+	upper, err := toNumber(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	n, _ := toFloat64(number)
+	lo, _ := toFloat64(lower)
+	hi, _ := toFloat64(upper)
+	if lo > hi {
+		return nil, fmt.Errorf("%w: clamp min %v is greater than max %v", ErrFilterArgsInvalid, lower, upper)
+	}
+
+	result := math.Min(math.Max(n, lo), hi)
+	_, numberIsInt := number.(int)
+	_, lowerIsInt := lower.(int)
+	_, upperIsInt := upper.(int)
+	if numberIsInt && lowerIsInt && upperIsInt {
+		return int(result), nil
+	}
+	return result, nil
+}
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -17,6 +18,48 @@ func TestMathFilters(t *testing.T) {
 			context:  map[string]interface{}{"value": -42},
 			expected: "42",
 		},
+		{
+			name:     "AbsFilterNegativeFloat",
+			template: "{{ value | abs }}",
+			context:  map[string]interface{}{"value": -2.5},
+			expected: "2.5",
+		},
+		{
+			name:     "AbsFilterZero",
+			template: "{{ value | abs }}",
+			context:  map[string]interface{}{"value": 0},
+			expected: "0",
+		},
+		{
+			name:     "AbsFilterChainedWithRound",
+			template: "{{ value | abs | round:1 }}",
+			context:  map[string]interface{}{"value": -3.14159},
+			expected: "3.1",
+		},
+		{
+			name:     "SignFilterNegativeInt",
+			template: "{{ value | sign }}",
+			context:  map[string]interface{}{"value": -42},
+			expected: "-1",
+		},
+		{
+			name:     "SignFilterNegativeFloat",
+			template: "{{ value | sign }}",
+			context:  map[string]interface{}{"value": -0.5},
+			expected: "-1",
+		},
+		{
+			name:     "SignFilterZero",
+			template: "{{ value | sign }}",
+			context:  map[string]interface{}{"value": 0},
+			expected: "0",
+		},
+		{
+			name:     "SignFilterPositiveChainedWithTimes",
+			template: "{{ value | sign | times:10 }}",
+			context:  map[string]interface{}{"value": 7.2},
+			expected: "10",
+		},
 		{
 			name:     "AtLeastFilter",
 			template: "{{ value | atLeast:10 }}",
@@ -29,6 +72,30 @@ func TestMathFilters(t *testing.T) {
 			context:  map[string]interface{}{"value": 15},
 			expected: "10",
 		},
+		{
+			name:     "ClampFilterBelowMin",
+			template: "{{ value | clamp:0,100 }}",
+			context:  map[string]interface{}{"value": -20},
+			expected: "0",
+		},
+		{
+			name:     "ClampFilterAboveMax",
+			template: "{{ value | clamp:0,100 }}",
+			context:  map[string]interface{}{"value": 150},
+			expected: "100",
+		},
+		{
+			name:     "ClampFilterWithinRange",
+			template: "{{ value | clamp:0,100 }}",
+			context:  map[string]interface{}{"value": 42.5},
+			expected: "42.5",
+		},
+		{
+			name:     "ClampFilterFloatBounds",
+			template: "{{ value | clamp:0.5,1.5 }}",
+			context:  map[string]interface{}{"value": 2},
+			expected: "1.5",
+		},
 		{
 			name:     "RoundFilter",
 			template: "{{ value | round:2 }}",
@@ -106,3 +173,87 @@ func TestMathFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestAbsFilterPreservesIntegers(t *testing.T) {
+	result, err := absFilter(-42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 42 {
+		t.Errorf("Expected int 42, got %#v", result)
+	}
+
+	result, err = absFilter(-2.5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 2.5 {
+		t.Errorf("Expected float64 2.5, got %#v", result)
+	}
+}
+
+func TestClampFilterInvalidBounds(t *testing.T) {
+	if _, err := clampFilter(5, "10", "1"); !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid, got %v", err)
+	}
+}
+
+func TestClampFilterResultTypes(t *testing.T) {
+	result, err := clampFilter(150, "0", "100")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 100 {
+		t.Errorf("Expected int 100, got %#v", result)
+	}
+
+	result, err = clampFilter(150, "0", "99.5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != 99.5 {
+		t.Errorf("Expected float64 99.5, got %#v", result)
+	}
+}
+
+func TestArithmeticResultTypes(t *testing.T) {
+	cases := []struct {
+		name     string
+		fn       FilterFunc
+		value    interface{}
+		arg      string
+		expected interface{}
+	}{
+		{"PlusInts", plusFilter, 599, "1", 600},
+		{"PlusIntString", plusFilter, "599", "1", 600},
+		{"PlusFloat", plusFilter, 599, "0.5", 599.5},
+		{"PlusFloatValue", plusFilter, 599.0, "1", 600.0},
+		{"MinusInts", minusFilter, 10, "15", -5},
+		{"MinusFloat", minusFilter, 10, "0.25", 9.75},
+		{"TimesInts", timesFilter, int64(3), "4", 12},
+		{"TimesFloat", timesFilter, 3, "1.5", 4.5},
+		{"ModuloInts", moduloFilter, 17, "5", 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.fn(tc.value, tc.arg)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+
+	ctx := NewContext()
+	ctx.Set("price", 599)
+	result, err := Render("{{ price | plus:1 | times:3 | minus:800 }} {{ price | plus:1 | times:0.5 }}", ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "1000 300" {
+		t.Errorf("Expected '1000 300', got '%s'", result)
+	}
+}
@@ -3,6 +3,9 @@ package template
 import (
 	"fmt"
 	"log"
+	"math"
+	"strconv"
+	"strings"
 
 	"github.com/kaptinlin/filter"
 )
@@ -10,8 +13,11 @@ import (
 func init() {
 	// Register number-related filters
 	filtersToRegister := map[string]FilterFunc{
-		"number": numberFilter,
-		"bytes":  bytesFilter,
+		"number":      numberFilter,
+		"bytes":       bytesFilter,
+		"floatformat": floatformatFilter,
+		"humanize":    humanizeFilter,
+		"humanbytes":  humanbytesFilter,
 	}
 
 	for name, filterFunc := range filtersToRegister {
@@ -19,6 +25,17 @@ func init() {
 			log.Printf("Error registering filter %s: %v", name, err)
 		}
 	}
+
+	// Register locale-aware number filters
+	templateFiltersToRegister := map[string]templateFilterFunc{
+		"currency": currencyFilter,
+	}
+
+	for name, filterFunc := range templateFiltersToRegister {
+		if err := registerTemplateFilter(name, filterFunc); err != nil {
+			log.Printf("Error registering filter %s: %v", name, err)
+		}
+	}
 }
 
 // numberFilter formats a numeric value according to the specified format string.
@@ -34,3 +51,111 @@ func numberFilter(value interface{}, args ...string) (interface{}, error) {
 func bytesFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Bytes(value)
 }
+
+// floatformatFilter formats a number with a fixed number of decimal places, keeping trailing zeros.
+// Without an argument the shortest representation is used, matching the default output.
+func floatformatFilter(value interface{}, args ...string) (interface{}, error) {
+	number, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) < 1 {
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	}
+	precision, err := strconv.Atoi(args[0])
+	if err != nil || precision < 0 {
+		return nil, fmt.Errorf("%w: floatformat precision must be a non-negative integer, got '%s'", ErrFilterArgsInvalid, args[0])
+	}
+	return strconv.FormatFloat(number, 'f', precision, 64), nil
+}
+
+// humanizeFilter abbreviates a large count with K, M, B, or T, so 1500000 becomes 1.5M.
+// An optional argument sets the maximum number of decimal places, 1 by default.
+func humanizeFilter(value interface{}, args ...string) (interface{}, error) {
+	number, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	precision, err := precisionArg("humanize", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	scaled, unit := scaleNumber(number, 1000, precision, []string{"", "K", "M", "B", "T"})
+	formatted := strconv.FormatFloat(scaled, 'f', precision, 64)
+	if strings.Contains(formatted, ".") {
+		formatted = strings.TrimSuffix(strings.TrimRight(formatted, "0"), ".")
+	}
+	return formatted + unit, nil
+}
+
+// humanbytesFilter formats a byte count with B, KB, MB, GB, TB, or PB, so 2048 becomes 2.0 KB.
+// Optional arguments set the number of decimal places, 1 by default, and the base: 2 (1 KB = 1024 B,
+// the default) or 10 (1 KB = 1000 B).
+func humanbytesFilter(value interface{}, args ...string) (interface{}, error) {
+	number, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	precision, err := precisionArg("humanbytes", args, 1)
+	if err != nil {
+		return nil, err
+	}
+	base := 1024.0
+	if len(args) > 1 {
+		switch args[1] {
+		case "2":
+		case "10":
+			base = 1000
+		default:
+			return nil, fmt.Errorf("%w: humanbytes base must be 2 or 10, got '%s'", ErrFilterArgsInvalid, args[1])
+		}
+	}
+
+	scaled, unit := scaleNumber(number, base, precision, []string{"B", "KB", "MB", "GB", "TB", "PB"})
+	if unit == "B" {
+		return strconv.FormatFloat(scaled, 'f', 0, 64) + " B", nil
+	}
+	return strconv.FormatFloat(scaled, 'f', precision, 64) + " " + unit, nil
+}
+
+// precisionArg parses the optional first argument of a filter as a number of decimal places.
+func precisionArg(name string, args []string, fallback int) (int, error) {
+	if len(args) < 1 {
+		return fallback, nil
+	}
+	precision, err := strconv.Atoi(args[0])
+	if err != nil || precision < 0 {
+		return 0, fmt.Errorf("%w: %s precision must be a non-negative integer, got '%s'", ErrFilterArgsInvalid, name, args[0])
+	}
+	return precision, nil
+}
+
+// scaleNumber divides a number by base until it is below base or the units run out, and returns
+// it with its unit. A value that rounds up to base at the given precision moves to the next unit.
+func scaleNumber(number, base float64, precision int, units []string) (float64, string) {
+	scaled := math.Abs(number)
+	i := 0
+	for scaled >= base && i < len(units)-1 {
+		scaled /= base
+		i++
+	}
+	factor := math.Pow(10, float64(precision))
+	if math.Round(scaled*factor)/factor >= base && i < len(units)-1 {
+		scaled /= base
+		i++
+	}
+	return math.Copysign(scaled, number), units[i]
+}
+
+// currencyFilter formats an amount of money for the template's locale. An optional argument overrides the currency symbol.
+func currencyFilter(t *Template, _ Context, value interface{}, args ...interface{}) (interface{}, error) {
+	amount, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	symbol := ""
+	if len(args) > 0 {
+		symbol = toString(args[0])
+	}
+	return t.Locale().FormatCurrency(amount, symbol), nil
+}
@@ -17,6 +17,36 @@ func TestNumberFilters(t *testing.T) {
 			context:  map[string]interface{}{"value": 1234567.89},
 			expected: "1,234,567.89",
 		},
+		{
+			name:     "DefaultFloatOutput",
+			template: "{{ value }}",
+			context:  map[string]interface{}{"value": 1000000.50},
+			expected: "1000000.5",
+		},
+		{
+			name:     "FloatformatFilterTwoPlaces",
+			template: "{{ value | floatformat:2 }}",
+			context:  map[string]interface{}{"value": 1000000.50},
+			expected: "1000000.50",
+		},
+		{
+			name:     "FloatformatFilterRounds",
+			template: "{{ value | floatformat:1 }}",
+			context:  map[string]interface{}{"value": 3.14159},
+			expected: "3.1",
+		},
+		{
+			name:     "FloatformatFilterInteger",
+			template: "{{ value | floatformat:2 }}",
+			context:  map[string]interface{}{"value": 42},
+			expected: "42.00",
+		},
+		{
+			name:     "FloatformatFilterWithoutPrecision",
+			template: "{{ value | floatformat }}",
+			context:  map[string]interface{}{"value": "2.50"},
+			expected: "2.5",
+		},
 		{
 			name:     "BytesFilterForKilobytes",
 			template: "{{ value | bytes }}",
@@ -35,6 +65,78 @@ func TestNumberFilters(t *testing.T) {
 			context:  map[string]interface{}{"value": 1073741824},
 			expected: "1.1 GB",
 		},
+		{
+			name:     "HumanizeSmall",
+			template: "{{ value | humanize }}",
+			context:  map[string]interface{}{"value": 950},
+			expected: "950",
+		},
+		{
+			name:     "HumanizeThousands",
+			template: "{{ value | humanize }}",
+			context:  map[string]interface{}{"value": 1500},
+			expected: "1.5K",
+		},
+		{
+			name:     "HumanizeMillions",
+			template: "{{ value | humanize }}",
+			context:  map[string]interface{}{"value": 1500000},
+			expected: "1.5M",
+		},
+		{
+			name:     "HumanizeBillionsWhole",
+			template: "{{ value | humanize }}",
+			context:  map[string]interface{}{"value": 2000000000},
+			expected: "2B",
+		},
+		{
+			name:     "HumanizeRoundsUpToNextUnit",
+			template: "{{ value | humanize }}",
+			context:  map[string]interface{}{"value": 999999},
+			expected: "1M",
+		},
+		{
+			name:     "HumanizePrecision",
+			template: "{{ value | humanize:2 }}",
+			context:  map[string]interface{}{"value": 1234567},
+			expected: "1.23M",
+		},
+		{
+			name:     "HumanizeNegative",
+			template: "{{ value | humanize }}",
+			context:  map[string]interface{}{"value": -42000},
+			expected: "-42K",
+		},
+		{
+			name:     "HumanbytesBytes",
+			template: "{{ value | humanbytes }}",
+			context:  map[string]interface{}{"value": 512},
+			expected: "512 B",
+		},
+		{
+			name:     "HumanbytesKilobytes",
+			template: "{{ value | humanbytes }}",
+			context:  map[string]interface{}{"value": 2048},
+			expected: "2.0 KB",
+		},
+		{
+			name:     "HumanbytesMegabytes",
+			template: "{{ value | humanbytes }}",
+			context:  map[string]interface{}{"value": 5242880},
+			expected: "5.0 MB",
+		},
+		{
+			name:     "HumanbytesGigabytesPrecision",
+			template: "{{ value | humanbytes:2 }}",
+			context:  map[string]interface{}{"value": 1610612736},
+			expected: "1.50 GB",
+		},
+		{
+			name:     "HumanbytesBase10",
+			template: "{{ value | humanbytes:1,10 }}",
+			context:  map[string]interface{}{"value": 1500000},
+			expected: "1.5 MB",
+		},
 	}
 
 	for _, tc := range cases {
@@ -1,8 +1,16 @@
 package template
 
 import (
+	"container/list"
 	"fmt"
 	"log"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/kaptinlin/filter"
 )
@@ -10,26 +18,40 @@ import (
 func init() {
 	// Register string-related filters
 	filtersToRegister := map[string]FilterFunc{
-		"default":       defaultFilter,
-		"trim":          trimFilter,
-		"split":         splitFilter,
-		"replace":       replaceFilter,
-		"remove":        removeFilter,
-		"append":        appendFilter,
-		"prepend":       prependFilter,
-		"length":        lengthFilter,
-		"upper":         upperFilter,
-		"lower":         lowerFilter,
-		"titleize":      titleizeFilter,
-		"capitalize":    capitalizeFilter,
-		"camelize":      camelizeFilter,
-		"pascalize":     pascalizeFilter,
-		"dasherize":     dasherizeFilter,
-		"slugify":       slugifyFilter,
-		"pluralize":     pluralizeFilter,
-		"ordinalize":    ordinalizeFilter,
-		"truncate":      truncateFilter,
-		"truncateWords": truncateWordsFilter,
+		"default":         defaultFilter,
+		"default_if_none": defaultIfNoneFilter,
+		"trim":            trimFilter,
+		"trimprefix":      trimPrefixFilter,
+		"trimsuffix":      trimSuffixFilter,
+		"split":           splitFilter,
+		"splitlines":      splitlinesFilter,
+		"replace":         replaceFilter,
+		"remove":          removeFilter,
+		"append":          appendFilter,
+		"prepend":         prependFilter,
+		"length":          lengthFilter,
+		"wordcount":       wordcountFilter,
+		"upper":           upperFilter,
+		"lower":           lowerFilter,
+		"titleize":        titleizeFilter,
+		"capitalize":      capitalizeFilter,
+		"ucfirst":         ucfirstFilter,
+		"camelize":        camelizeFilter,
+		"pascalize":       pascalizeFilter,
+		"dasherize":       dasherizeFilter,
+		"slugify":         slugifyFilter,
+		"pluralize":       pluralizeFilter,
+		"plural":          pluralFilter,
+		"ordinalize":      ordinalizeFilter,
+		"truncate":        truncateFilter,
+		"truncateWords":   truncateWordsFilter,
+		"matches":         matchesFilter,
+		"regex_match":     matchesFilter,
+		"regex_replace":   regexReplaceFilter,
+		"indent":          indentFilter,
+		"wordwrap":        wordwrapFilter,
+		"ljust":           ljustFilter,
+		"rjust":           rjustFilter,
 	}
 
 	for name, filterFunc := range filtersToRegister {
@@ -37,6 +59,9 @@ func init() {
 			log.Printf("Error registering filter %s: %v", name, err)
 		}
 	}
+
+	// default_if_none also replaces variables that are missing from the context.
+	acceptMissingInput("default_if_none")
 }
 
 // defaultFilter returns a default value if the input string is empty.
@@ -48,29 +73,98 @@ func defaultFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Default(toString(value), defaultValue), nil
 }
 
+// defaultIfNoneFilter returns a default value only if the input is nil or missing from the context.
+// Empty strings, zero, and false are kept as they are.
+func defaultIfNoneFilter(value interface{}, args ...string) (interface{}, error) {
+	if value != nil && !isNilPointer(value) {
+		return value, nil
+	}
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	return "", nil
+}
+
 // trimFilter removes leading and trailing whitespace from the string.
 func trimFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Trim(toString(value)), nil
 }
 
+// trimPrefixFilter removes a leading prefix from a string if present.
+func trimPrefixFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: trimprefix filter requires a prefix argument", ErrInsufficientArgs)
+	}
+	return strings.TrimPrefix(toString(value), args[0]), nil
+}
+
+// trimSuffixFilter removes a trailing suffix from a string if present.
+func trimSuffixFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: trimsuffix filter requires a suffix argument", ErrInsufficientArgs)
+	}
+	return strings.TrimSuffix(toString(value), args[0]), nil
+}
+
 // splitFilter divides a string into a slice of strings based on a specified delimiter.
+// An optional second argument limits the number of parts, leaving the remainder unsplit in the last part.
 func splitFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 1 {
 		return nil, fmt.Errorf("%w: split filter requires a delimiter argument", ErrInsufficientArgs)
 	}
 	delimiter := args[0]
+	if len(args) > 1 {
+		limit, err := strconv.Atoi(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: split filter limit must be an integer, got '%s'", ErrFilterArgsInvalid, args[1])
+		}
+		return strings.SplitN(toString(value), delimiter, limit), nil
+	}
 	return filter.Split(toString(value), delimiter), nil
 }
 
+// lineBreaks matches any newline convention.
+var lineBreaks = regexp.MustCompile(`\r\n|\r|\n`)
+
+// splitlinesFilter splits a string into lines on \n, \r\n, or \r. A final line break does not produce an empty line.
+func splitlinesFilter(value interface{}, args ...string) (interface{}, error) {
+	str := toString(value)
+	if str == "" {
+		return []string{}, nil
+	}
+	lines := lineBreaks.Split(str, -1)
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
 // replaceFilter substitutes all instances of a specified substring with another string.
+// An optional third argument limits the number of replacements, starting from the left.
 func replaceFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 2 {
 		return nil, fmt.Errorf("%w: replace filter requires two arguments (old and new substrings)", ErrInsufficientArgs)
 	}
 	old, new := args[0], args[1]
+	if len(args) > 2 {
+		limit, err := replaceLimit("replace", args[2])
+		if err != nil {
+			return nil, err
+		}
+		return strings.Replace(toString(value), old, new, limit), nil
+	}
 	return filter.Replace(toString(value), old, new), nil
 }
 
+// replaceLimit parses the optional replacement count of the replace filters. A negative count means no limit.
+func replaceLimit(name, arg string) (int, error) {
+	limit, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s filter limit must be an integer, got '%s'", ErrFilterArgsInvalid, name, arg)
+	}
+	return limit, nil
+}
+
 // removeFilter eliminates all occurrences of a specified substring.
 func removeFilter(value interface{}, args ...string) (interface{}, error) {
 	if len(args) < 1 {
@@ -98,9 +192,20 @@ func prependFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Prepend(toString(value), toPrepend), nil
 }
 
-// lengthFilter returns the number of characters in a string.
+// lengthFilter returns the number of characters in a string, or the number of elements in a slice, array, or map.
 func lengthFilter(value interface{}, args ...string) (interface{}, error) {
-	return filter.Length(toString(value)), nil
+	valRef := reflect.ValueOf(dereferenceIfNeeded(value))
+	switch valRef.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return valRef.Len(), nil
+	default:
+		return filter.Length(toString(value)), nil
+	}
+}
+
+// wordcountFilter counts the words in a string, where words are separated by any amount of whitespace.
+func wordcountFilter(value interface{}, args ...string) (interface{}, error) {
+	return len(strings.Fields(toString(value))), nil
 }
 
 // upperFilter converts all characters in a string to uppercase.
@@ -123,6 +228,17 @@ func capitalizeFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Capitalize(toString(value)), nil
 }
 
+// ucfirstFilter uppercases only the first character of the string and leaves the rest untouched.
+// Unlike capitalize, which uses title case, it uses the upper-case form of the first character.
+func ucfirstFilter(value interface{}, args ...string) (interface{}, error) {
+	str := toString(value)
+	first, size := utf8.DecodeRuneInString(str)
+	if size == 0 {
+		return str, nil
+	}
+	return string(unicode.ToUpper(first)) + str[size:], nil
+}
+
 // camelizeFilter converts a string to camelCase.
 func camelizeFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Camelize(toString(value)), nil
@@ -156,6 +272,22 @@ func pluralizeFilter(value interface{}, args ...string) (interface{}, error) {
 	return filter.Pluralize(count, singular, plural), nil
 }
 
+// pluralFilter returns the singular or plural word based on a numeric value, without formatting the count.
+// Any count other than exactly one, including zero, selects the plural form.
+func pluralFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: plural filter requires two arguments (singular and plural forms)", ErrInsufficientArgs)
+	}
+	count, err := toFloat64(value)
+	if err != nil {
+		return nil, err
+	}
+	if count == 1 {
+		return args[0], nil
+	}
+	return args[1], nil
+}
+
 // ordinalizeFilter converts a number to its ordinal English form.
 func ordinalizeFilter(value interface{}, args ...string) (interface{}, error) {
 	number, err := toInteger(value)
@@ -188,3 +320,202 @@ func truncateWordsFilter(value interface{}, args ...string) (interface{}, error)
 	}
 	return filter.TruncateWords(toString(value), maxWords), nil
 }
+
+// regexCacheSize is the number of compiled patterns kept by regexCache.
+const regexCacheSize = 128
+
+// regexCache keeps the most recently used compiled patterns, so patterns taken from template data
+// cannot grow it without bound.
+var regexCache = &regexLRU{entries: make(map[string]*list.Element), order: list.New()}
+
+// regexLRU is a least-recently-used cache of compiled regular expressions keyed by their source.
+type regexLRU struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// get returns the cached expression for a pattern and marks it as recently used.
+func (c *regexLRU) get(pattern string) (*regexp.Regexp, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[pattern]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*regexp.Regexp), true
+}
+
+// add caches an expression, evicting the least recently used one when the cache is full.
+func (c *regexLRU) add(re *regexp.Regexp) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[re.String()]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[re.String()] = c.order.PushFront(re)
+	if c.order.Len() > regexCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexp.Regexp).String())
+	}
+}
+
+// compileRegex returns a cached compiled regular expression for the given pattern.
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.get(pattern); ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid regular expression '%s': %w", ErrFilterArgsInvalid, pattern, err)
+	}
+	regexCache.add(re)
+	return re, nil
+}
+
+// matchesFilter reports whether the string matches the given regular expression.
+func matchesFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: matches filter requires a pattern argument", ErrInsufficientArgs)
+	}
+	re, err := compileRegex(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(toString(value)), nil
+}
+
+// regexReplaceFilter replaces matches of a regular expression, expanding $1 or ${name} in the replacement.
+// An optional third argument limits the number of replacements, starting from the left.
+func regexReplaceFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%w: regex_replace filter requires two arguments (pattern and replacement)", ErrInsufficientArgs)
+	}
+	re, err := compileRegex(args[0])
+	if err != nil {
+		return nil, err
+	}
+	limit := -1
+	if len(args) > 2 {
+		if limit, err = replaceLimit("regex_replace", args[2]); err != nil {
+			return nil, err
+		}
+	}
+
+	str := toString(value)
+	var result []byte
+	last := 0
+	for _, match := range re.FindAllStringSubmatchIndex(str, limit) {
+		result = append(result, str[last:match[0]]...)
+		result = re.ExpandString(result, args[1], str, match)
+		last = match[1]
+	}
+	return string(append(result, str[last:]...)), nil
+}
+
+// indentFilter prefixes every line of a string with the given number of spaces.
+// An optional second boolean argument set to false leaves the first line unindented.
+func indentFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: indent filter requires a width argument", ErrInsufficientArgs)
+	}
+	width, err := toInteger(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if width < 0 {
+		return nil, fmt.Errorf("%w: indent width must not be negative, got %d", ErrFilterArgsInvalid, width)
+	}
+	indentFirst := true
+	if len(args) > 1 {
+		indentFirst, err = strconv.ParseBool(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: indent filter expects a boolean second argument, got '%s'", ErrFilterArgsInvalid, args[1])
+		}
+	}
+
+	prefix := strings.Repeat(" ", width)
+	lines := strings.Split(toString(value), "\n")
+	for i := range lines {
+		if i == 0 && !indentFirst {
+			continue
+		}
+		lines[i] = prefix + lines[i]
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// wordwrapFilter inserts line breaks so that no line exceeds the given width, breaking on spaces.
+// Existing line breaks are preserved, and words longer than the width are kept whole on their own line.
+func wordwrapFilter(value interface{}, args ...string) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: wordwrap filter requires a width argument", ErrInsufficientArgs)
+	}
+	width, err := toInteger(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("%w: wordwrap width must be positive, got %d", ErrFilterArgsInvalid, width)
+	}
+
+	lines := strings.Split(toString(value), "\n")
+	for i, line := range lines {
+		var builder strings.Builder
+		lineLength := 0
+		for _, word := range strings.Fields(line) {
+			wordLength := utf8.RuneCountInString(word)
+			if lineLength > 0 && lineLength+1+wordLength > width {
+				builder.WriteString("\n")
+				lineLength = 0
+			} else if lineLength > 0 {
+				builder.WriteString(" ")
+				lineLength++
+			}
+			builder.WriteString(word)
+			lineLength += wordLength
+		}
+		lines[i] = builder.String()
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ljustFilter pads a string on the right to the given width.
+func ljustFilter(value interface{}, args ...string) (interface{}, error) {
+	return justify("ljust", value, args, false)
+}
+
+// rjustFilter pads a string on the left to the given width.
+func rjustFilter(value interface{}, args ...string) (interface{}, error) {
+	return justify("rjust", value, args, true)
+}
+
+// justify pads a string to a width counted in runes, using an optional fill character that defaults to a space.
+// Strings already at or beyond the width are returned unchanged.
+func justify(name string, value interface{}, args []string, padLeft bool) (interface{}, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("%w: %s filter requires a width argument", ErrInsufficientArgs, name)
+	}
+	width, err := toInteger(args[0])
+	if err != nil {
+		return nil, err
+	}
+	fill := " "
+	if len(args) > 1 && args[1] != "" {
+		r, _ := utf8.DecodeRuneInString(args[1])
+		fill = string(r)
+	}
+
+	str := toString(value)
+	padding := width - utf8.RuneCountInString(str)
+	if padding <= 0 {
+		return str, nil
+	}
+	if padLeft {
+		return strings.Repeat(fill, padding) + str, nil
+	}
+	return str + strings.Repeat(fill, padding), nil
+}
@@ -1,6 +1,8 @@
 package template
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -17,6 +19,28 @@ func TestStringFilters(t *testing.T) {
 			context:  map[string]interface{}{"name": ""},
 			expected: "Unknown",
 		},
+		{
+			name:     "DefaultIfNoneFilterKeepsEmptyValues",
+			template: "[{{ name | default_if_none:'Unknown' }}] [{{ count | default_if_none:'none' }}]",
+			context:  map[string]interface{}{"name": "", "count": 0},
+			expected: "[] [0]",
+		},
+		{
+			name:     "DefaultIfNoneFilterReplacesNil",
+			template: "{{ name | default_if_none:'Unknown' }}",
+			context:  map[string]interface{}{"name": nil},
+			expected: "Unknown",
+		},
+		{
+			name:     "DefaultIfNoneFilterReplacesMissing",
+			template: "{{ user.name | default_if_none:'Unknown' | upper }}",
+			expected: "UNKNOWN",
+		},
+		{
+			name:     "DefaultIfNoneFilterLaterInChain",
+			template: "{{ user.name | lower | default_if_none:'Unknown' }}",
+			expected: "Unknown",
+		},
 		{
 			name:     "TrimFilter",
 			template: "{{ '  hello  ' | trim }}",
@@ -27,6 +51,23 @@ func TestStringFilters(t *testing.T) {
 			template: "{{ 'one,two,three' | split:',' | size }}",
 			expected: "3",
 		},
+		{
+			name:     "SplitFilterWithLimit",
+			template: "{{ 'key=value=more' | split:'=',2 | join:'|' }}",
+			expected: "key|value=more",
+		},
+		{
+			name:     "SplitlinesFilterMixedNewlines",
+			template: "{{ text | splitlines | join:',' }}",
+			context:  map[string]interface{}{"text": "one\r\ntwo\nthree\rfour\n"},
+			expected: "one,two,three,four",
+		},
+		{
+			name:     "SplitlinesFilterKeepsBlankLines",
+			template: "{{ text | splitlines | size }}",
+			context:  map[string]interface{}{"text": "one\r\n\r\nthree"},
+			expected: "3",
+		},
 		{
 			name:     "ReplaceFilter",
 			template: "{{ 'hello world' | replace:'world','there' }}",
@@ -52,6 +93,28 @@ func TestStringFilters(t *testing.T) {
 			template: "{{ 'hello' | length }}",
 			expected: "5",
 		},
+		{
+			name:     "LengthFilterSlice",
+			template: "{{ items | length }}",
+			context:  map[string]interface{}{"items": []string{"apple", "banana"}},
+			expected: "2",
+		},
+		{
+			name:     "WordcountFilterEmpty",
+			template: "{{ '' | wordcount }}",
+			expected: "0",
+		},
+		{
+			name:     "WordcountFilterSingleWord",
+			template: "{{ 'hello' | wordcount }}",
+			expected: "1",
+		},
+		{
+			name:     "WordcountFilterMixedWhitespace",
+			template: "{{ bio | wordcount }}",
+			context:  map[string]interface{}{"bio": "  Writes   Go\tcode,\n\nreads books  "},
+			expected: "5",
+		},
 		{
 			name:     "UpperFilter",
 			template: "{{ 'hello' | upper }}",
@@ -67,11 +130,91 @@ func TestStringFilters(t *testing.T) {
 			template: "{{ 'hello world' | titleize }}",
 			expected: "Hello World",
 		},
+		{
+			name:     "TrimprefixFilterPresent",
+			template: "{{ '/api/users' | trimprefix:'/api' }}",
+			expected: "/users",
+		},
+		{
+			name:     "TrimprefixFilterAbsent",
+			template: "{{ '/web/users' | trimprefix:'/api' }}",
+			expected: "/web/users",
+		},
+		{
+			name:     "TrimprefixFilterOnlyOnce",
+			template: "{{ 'aaab' | trimprefix:'a' }}",
+			expected: "aab",
+		},
+		{
+			name:     "TrimsuffixFilterPresent",
+			template: "{{ 'main.go' | trimsuffix:'.go' }}",
+			expected: "main",
+		},
+		{
+			name:     "TrimsuffixFilterAbsent",
+			template: "{{ 'main.rs' | trimsuffix:'.go' }}",
+			expected: "main.rs",
+		},
+		{
+			name:     "ReplaceFilterWithLimit",
+			template: "{{ 'a-b-c-d' | replace:'-','+',1 }}",
+			expected: "a+b-c-d",
+		},
+		{
+			name:     "ReplaceFilterWithLimitTwo",
+			template: "{{ 'a-b-c-d' | replace:'-','+',2 }}",
+			expected: "a+b+c-d",
+		},
+		{
+			name:     "ReplaceFilterWithNegativeLimit",
+			template: "{{ 'a-b-c-d' | replace:'-','+',-1 }}",
+			expected: "a+b+c+d",
+		},
+		{
+			name:     "ReplaceFilterAll",
+			template: "{{ 'a-b-c-d' | replace:'-','+' }}",
+			expected: "a+b+c+d",
+		},
+		{
+			name:     "RegexReplaceFilter",
+			template: "{{ 'order 12, item 345' | regex_replace:'[0-9]+','#' }}",
+			expected: "order #, item #",
+		},
+		{
+			name:     "RegexReplaceFilterWithGroupsAndLimit",
+			template: "{{ '2024-03-05 and 2025-01-02' | regex_replace:'(\\d+)-(\\d+)-(\\d+)','$3/$2/$1',1 }}",
+			expected: "05/03/2024 and 2025-01-02",
+		},
 		{
 			name:     "CapitalizeFilter",
 			template: "{{ 'hello' | capitalize }}",
 			expected: "Hello",
 		},
+		{
+			name:     "CapitalizeFilterMultiWord",
+			template: "{{ 'hello wORLD again' | capitalize }}",
+			expected: "Hello wORLD again",
+		},
+		{
+			name:     "UcfirstFilterMultiWord",
+			template: "{{ 'hello wORLD again' | ucfirst }}",
+			expected: "Hello wORLD again",
+		},
+		{
+			name:     "CapitalizeFilterTitleCaseDigraph",
+			template: "{{ 'ǆemal' | capitalize }}",
+			expected: "ǅemal",
+		},
+		{
+			name:     "UcfirstFilterUpperCaseDigraph",
+			template: "{{ 'ǆemal' | ucfirst }}",
+			expected: "Ǆemal",
+		},
+		{
+			name:     "UcfirstFilterEmpty",
+			template: "{{ '' | ucfirst }}",
+			expected: "",
+		},
 		{
 			name:     "CamelizeFilter",
 			template: "{{ 'hello_world' | camelize }}",
@@ -104,6 +247,48 @@ func TestStringFilters(t *testing.T) {
 			context:  map[string]interface{}{"count": 2},
 			expected: "apples",
 		},
+		{
+			name:     "PluralizeFilterWithCountZero",
+			template: "{{ count | pluralize:'%d item','%d items' }}",
+			context:  map[string]interface{}{"count": 0},
+			expected: "0 items",
+		},
+		{
+			name:     "PluralizeFilterWithCountOne",
+			template: "{{ count | pluralize:'%d item','%d items' }}",
+			context:  map[string]interface{}{"count": 1},
+			expected: "1 item",
+		},
+		{
+			name:     "PluralizeFilterWithCountTwo",
+			template: "{{ count | pluralize:'%d item','%d items' }}",
+			context:  map[string]interface{}{"count": 2},
+			expected: "2 items",
+		},
+		{
+			name:     "PluralFilterWithCountZero",
+			template: "{{ count | plural:'child','children' }}",
+			context:  map[string]interface{}{"count": 0},
+			expected: "children",
+		},
+		{
+			name:     "PluralFilterWithCountOne",
+			template: "{{ count | plural:'child','children' }}",
+			context:  map[string]interface{}{"count": 1},
+			expected: "child",
+		},
+		{
+			name:     "PluralFilterWithCountTwo",
+			template: "{{ count | plural:'child','children' }}",
+			context:  map[string]interface{}{"count": 2},
+			expected: "children",
+		},
+		{
+			name:     "PluralFilterWithFractionalCount",
+			template: "{{ count | plural:'item','items' }}",
+			context:  map[string]interface{}{"count": 1.5},
+			expected: "items",
+		},
 		{
 			name:     "OrdinalizeFilter",
 			template: "{{ '1' | ordinalize }}",
@@ -119,6 +304,84 @@ func TestStringFilters(t *testing.T) {
 			template: "{{ 'hello beautiful world' | truncateWords:2 }}",
 			expected: "hello beautiful...",
 		},
+		{
+			name:     "MatchesFilterMatching",
+			template: `{{ email | matches:"^[^@]+@[^@]+$" }}`,
+			context:  map[string]interface{}{"email": "jane@example.com"},
+			expected: "true",
+		},
+		{
+			name:     "MatchesFilterNotMatching",
+			template: `{{ email | matches:"^[^@]+@[^@]+$" }}`,
+			context:  map[string]interface{}{"email": "not-an-email"},
+			expected: "false",
+		},
+		{
+			name:     "MatchesFilterWithPipeInPattern",
+			template: `{{ status | regex_match:"^(active|pending)$" }}`,
+			context:  map[string]interface{}{"status": "pending"},
+			expected: "true",
+		},
+		{
+			name:     "IndentFilter",
+			template: "{{ text | indent:4 }}",
+			context:  map[string]interface{}{"text": "first\nsecond\nthird"},
+			expected: "    first\n    second\n    third",
+		},
+		{
+			name:     "IndentFilterSkippingFirstLine",
+			template: "{{ text | indent:2,false }}",
+			context:  map[string]interface{}{"text": "first\nsecond\nthird"},
+			expected: "first\n  second\n  third",
+		},
+		{
+			name:     "WordwrapFilter",
+			template: "{{ text | wordwrap:20 }}",
+			context:  map[string]interface{}{"text": "The quick brown fox jumps over the lazy dog and keeps running"},
+			expected: "The quick brown fox\njumps over the lazy\ndog and keeps\nrunning",
+		},
+		{
+			name:     "WordwrapFilterPreservesNewlines",
+			template: "{{ text | wordwrap:20 }}",
+			context:  map[string]interface{}{"text": "Short line\nAnother short one\n\nAfter a blank line"},
+			expected: "Short line\nAnother short one\n\nAfter a blank line",
+		},
+		{
+			name:     "WordwrapFilterLongWordOverflows",
+			template: "{{ text | wordwrap:5 }}",
+			context:  map[string]interface{}{"text": "a supercalifragilistic word"},
+			expected: "a\nsupercalifragilistic\nword",
+		},
+		{
+			name:     "LjustFilter",
+			template: "[{{ name | ljust:8 }}]",
+			context:  map[string]interface{}{"name": "Jane"},
+			expected: "[Jane    ]",
+		},
+		{
+			name:     "RjustFilter",
+			template: "[{{ name | rjust:8 }}]",
+			context:  map[string]interface{}{"name": "Jane"},
+			expected: "[    Jane]",
+		},
+		{
+			name:     "RjustFilterWithFillChar",
+			template: "{{ amount | rjust:6,'0' }}",
+			context:  map[string]interface{}{"amount": 42},
+			expected: "000042",
+		},
+		{
+			name:     "LjustFilterCountsRunes",
+			template: "{{ name | ljust:6,'.' }}",
+			context:  map[string]interface{}{"name": "Zoë"},
+			expected: "Zoë...",
+		},
+		{
+			name:     "LjustFilterOverWidth",
+			template: "{{ name | ljust:3 }}",
+			context:  map[string]interface{}{"name": "Jonathan"},
+			expected: "Jonathan",
+		},
 	}
 
 	for _, tc := range cases {
@@ -148,3 +411,53 @@ func TestStringFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestPluralFilterRequiresTwoForms(t *testing.T) {
+	_, err := Render(`{{ n|plural:"child" }}`, Context{"n": 2})
+	if !errors.Is(err, ErrInsufficientArgs) {
+		t.Errorf("Expected ErrInsufficientArgs, got %v", err)
+	}
+}
+
+func TestRegexCacheIsBounded(t *testing.T) {
+	for i := 0; i < regexCacheSize*2; i++ {
+		if _, err := compileRegex(fmt.Sprintf("^item-%d$", i)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(regexCache.entries) != regexCacheSize || regexCache.order.Len() != regexCacheSize {
+		t.Errorf("Expected the cache to hold %d patterns, got %d", regexCacheSize, len(regexCache.entries))
+	}
+	if _, ok := regexCache.get("^item-0$"); ok {
+		t.Error("Expected the least recently used pattern to be evicted")
+	}
+	if _, ok := regexCache.get(fmt.Sprintf("^item-%d$", regexCacheSize*2-1)); !ok {
+		t.Error("Expected the most recent pattern to be cached")
+	}
+}
+
+func TestMatchesFilterInvalidPattern(t *testing.T) {
+	_, err := matchesFilter("value", "[unclosed")
+	if !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Fatalf("Expected ErrFilterArgsInvalid, got %v", err)
+	}
+
+	tpl, err := Parse(`{{ value | matches:"[unclosed" }}`)
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	if _, err := tpl.Execute(Context{"value": "abc"}); !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid from Execute, got %v", err)
+	}
+}
+
+func TestIndentFilterNegativeWidth(t *testing.T) {
+	if _, err := indentFilter("text", "-2"); !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid, got %v", err)
+	}
+
+	result, err := Render("{{ s|indent:-2 }}", Context{"s": "text"})
+	if !errors.Is(err, ErrFilterArgsInvalid) {
+		t.Errorf("Expected ErrFilterArgsInvalid from Render, got %v with output '%s'", err, result)
+	}
+}
@@ -2,6 +2,10 @@ package template
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/kaptinlin/filter"
@@ -38,8 +42,34 @@ func (c Context) Set(key string, value interface{}) {
 	}
 }
 
+// SetDefault inserts a variable only when the key is absent, supporting nested keys like Set.
+// An existing value is kept even when it is nil or empty. It reports whether the value was set.
+func (c Context) SetDefault(key string, value interface{}) bool {
+	if _, err := c.Get(key); !errors.Is(err, ErrContextKeyNotFound) {
+		return false
+	}
+	c.Set(key, value)
+	return true
+}
+
+// Resolver is implemented by context values that look up paths themselves, such as lazily loaded
+// or database-backed objects. When a path reaches a Resolver, the rest of the path is passed to it.
+type Resolver interface {
+	// Resolve returns the value at a dot-separated path relative to the resolver, and whether it exists.
+	Resolve(path string) (interface{}, bool)
+}
+
 // Get retrieves a variable's value from the Context, supporting nested keys.
 func (c Context) Get(key string) (interface{}, error) {
+	return c.get(key, true)
+}
+
+// get retrieves a variable's value, calling zero-argument methods along the path only when callMethods is set.
+func (c Context) get(key string, callMethods bool) (interface{}, error) {
+	if value, found, err := resolvePath(c, key, callMethods); err != nil || found {
+		return value, err
+	}
+
 	value, err := filter.Extract(c, key)
 	if err != nil {
 		switch {
@@ -55,3 +85,163 @@ func (c Context) Get(key string) (interface{}, error) {
 	}
 	return value, nil
 }
+
+// GetString retrieves a variable and formats it as text, the same way a {{ }} tag would render it.
+func (c Context) GetString(key string) (string, error) {
+	value, err := c.Get(key)
+	if err != nil {
+		return "", err
+	}
+	str, err := convertToString(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: '%s' cannot be rendered as text: %w", ErrContextValueType, key, err)
+	}
+	return str, nil
+}
+
+// GetInt retrieves a variable as an int. Integers, whole floats, and numeric strings are converted;
+// fractional numbers and other values are rejected.
+func (c Context) GetInt(key string) (int, error) {
+	value, err := c.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	number, err := toNumber(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: '%s' is %T, not an integer", ErrContextValueType, key, value)
+	}
+	switch n := number.(type) {
+	case int:
+		return n, nil
+	case float64:
+		// float64(math.MaxInt) rounds up to 2^63, so the upper bound is exclusive.
+		if n == math.Trunc(n) && n >= math.MinInt && n < -float64(math.MinInt) {
+			return int(n), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: '%s' is %v, not an integer", ErrContextValueType, key, value)
+}
+
+// GetBool retrieves a variable as a bool. Strings accepted by strconv.ParseBool, such as "true" and "0",
+// are converted; other values are rejected.
+func (c Context) GetBool(key string) (bool, error) {
+	value, err := c.Get(key)
+	if err != nil {
+		return false, err
+	}
+	switch v := dereferenceIfNeeded(value).(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b, nil
+		}
+	}
+	return false, fmt.Errorf("%w: '%s' is %T, not a bool", ErrContextValueType, key, value)
+}
+
+// resolvePath follows a dot-separated path through maps, slices, struct fields, and, when callMethods
+// is set, zero-argument methods, handing the remainder to the first Resolver it meets. It reports found
+// as false when a segment cannot be resolved, leaving the caller to classify the failure.
+func resolvePath(input interface{}, key string, callMethods bool) (value interface{}, found bool, err error) {
+	current := input
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		next, ok, err := resolveSegment(current, part, callMethods)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: '%s': %w", ErrContextMethodCall, key, err)
+		}
+		if !ok {
+			return nil, false, nil
+		}
+		if resolver, ok := next.(Resolver); ok && i < len(parts)-1 {
+			value, found := resolver.Resolve(strings.Join(parts[i+1:], "."))
+			if !found {
+				return nil, false, ErrContextKeyNotFound
+			}
+			return value, true, nil
+		}
+		current = next
+	}
+	return current, true, nil
+}
+
+// RenderValue formats a value exactly as a {{ }} tag would render it, so custom filters that
+// produce text can match built-in interpolation for maps, slices, times, and numbers.
+func (c Context) RenderValue(value interface{}) (string, error) {
+	return convertToString(value)
+}
+
+// ReadOnlyContext is a frozen snapshot of a Context. Reads work as usual while writes are rejected,
+// so a base context can be shared safely across requests.
+type ReadOnlyContext struct {
+	ctx Context
+}
+
+// Freeze returns a read-only snapshot of the Context. Nested maps, contexts, and slices are copied, so later
+// changes to the Context do not affect the snapshot; values such as pointers and structs are shared.
+func (c Context) Freeze() ReadOnlyContext {
+	return ReadOnlyContext{ctx: copyContext(c)}
+}
+
+// Get retrieves a variable's value from the frozen context, supporting nested keys.
+func (r ReadOnlyContext) Get(key string) (interface{}, error) {
+	return r.ctx.Get(key)
+}
+
+// Set always fails with ErrContextReadOnly.
+func (r ReadOnlyContext) Set(key string, value interface{}) error {
+	return fmt.Errorf("%w: cannot set '%s'", ErrContextReadOnly, key)
+}
+
+// SetDefault always fails with ErrContextReadOnly.
+func (r ReadOnlyContext) SetDefault(key string, value interface{}) error {
+	return fmt.Errorf("%w: cannot set '%s'", ErrContextReadOnly, key)
+}
+
+// Clone returns a writable copy of the frozen context, for example to add per-request values before rendering.
+func (r ReadOnlyContext) Clone() Context {
+	return copyContext(r.ctx)
+}
+
+// copyContext copies a Context along with its nested maps, contexts, and slices.
+func copyContext(c Context) Context {
+	return Context(copyNestedMap(c))
+}
+
+// copyNestedMap copies a map, recursing into its values with copyValue.
+func copyNestedMap(m map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		copied[key] = copyValue(value)
+	}
+	return copied
+}
+
+// copyValue deep copies maps of type map[string]interface{} or Context and slices of any type.
+// Other values are returned as they are.
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyNestedMap(v)
+	case Context:
+		return Context(copyNestedMap(v))
+	}
+
+	valRef := reflect.ValueOf(value)
+	if valRef.Kind() != reflect.Slice || valRef.IsNil() {
+		return value
+	}
+	copied := reflect.MakeSlice(valRef.Type(), valRef.Len(), valRef.Len())
+	for i := 0; i < valRef.Len(); i++ {
+		elem := valRef.Index(i)
+		switch elem.Kind() {
+		case reflect.Interface, reflect.Map, reflect.Slice:
+			if !elem.IsNil() {
+				elem = reflect.ValueOf(copyValue(elem.Interface()))
+			}
+		}
+		copied.Index(i).Set(elem)
+	}
+	return copied.Interface()
+}
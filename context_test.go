@@ -2,8 +2,11 @@ package template
 
 import (
 	"errors"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestEmptyContextInitialization(t *testing.T) {
@@ -399,3 +402,280 @@ func TestSimplifiedOverwritingValuesInContext(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefault(t *testing.T) {
+	testCases := []struct {
+		description string
+		key         string
+		value       interface{}
+		expectSet   bool
+		expected    interface{}
+	}{
+		{"Keeps existing top-level key", "theme", "light", false, "dark"},
+		{"Keeps existing empty value", "title", "Untitled", false, ""},
+		{"Keeps existing nested key", "user.name", "Guest", false, "Jane"},
+		{"Sets missing top-level key", "lang", "en", true, "en"},
+		{"Sets missing nested key beside existing ones", "user.role", "member", true, "member"},
+		{"Sets missing deep nested key", "settings.layout.sidebar", true, true, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			context := NewContext()
+			context.Set("theme", "dark")
+			context.Set("title", "")
+			context.Set("user.name", "Jane")
+
+			if set := context.SetDefault(testCase.key, testCase.value); set != testCase.expectSet {
+				t.Errorf("SetDefault('%s') returned %v, expected %v", testCase.key, set, testCase.expectSet)
+			}
+
+			value, err := context.Get(testCase.key)
+			if err != nil {
+				t.Fatalf("Unexpected error for '%s': %v", testCase.key, err)
+			}
+			if !reflect.DeepEqual(value, testCase.expected) {
+				t.Errorf("Get('%s') returned %v, expected %v", testCase.key, value, testCase.expected)
+			}
+			if name, _ := context.Get("user.name"); name != "Jane" {
+				t.Errorf("Expected sibling 'user.name' to be kept, got %v", name)
+			}
+		})
+	}
+}
+
+func TestFrozenContext(t *testing.T) {
+	base := NewContext()
+	base.Set("site.name", "Example")
+	base.Set("theme", "dark")
+
+	frozen := base.Freeze()
+
+	// Reads succeed.
+	if name, err := frozen.Get("site.name"); err != nil || name != "Example" {
+		t.Errorf("Expected 'Example', got %v (err: %v)", name, err)
+	}
+
+	// Writes are rejected.
+	if err := frozen.Set("theme", "light"); !errors.Is(err, ErrContextReadOnly) {
+		t.Errorf("Expected ErrContextReadOnly from Set, got %v", err)
+	}
+	if err := frozen.SetDefault("lang", "en"); !errors.Is(err, ErrContextReadOnly) {
+		t.Errorf("Expected ErrContextReadOnly from SetDefault, got %v", err)
+	}
+
+	// Changes to the original context or to a clone do not reach the frozen snapshot.
+	base.Set("site.name", "Changed")
+	request := frozen.Clone()
+	request.Set("site.name", "Per request")
+	request.Set("path", "/about")
+
+	if name, _ := frozen.Get("site.name"); name != "Example" {
+		t.Errorf("Expected frozen 'site.name' to stay 'Example', got %v", name)
+	}
+	if _, err := frozen.Get("path"); !errors.Is(err, ErrContextKeyNotFound) {
+		t.Errorf("Expected 'path' to be missing from the frozen context, got %v", err)
+	}
+
+	result, err := Render("{{ site.name }} {{ theme }} {{ path }}", request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "Per request dark /about" {
+		t.Errorf("Expected 'Per request dark /about', got '%s'", result)
+	}
+}
+
+func TestFrozenContextCopiesNestedValues(t *testing.T) {
+	settings := Context{"theme": "dark"}
+	tags := []string{"a", "b"}
+	items := []interface{}{map[string]interface{}{"name": "first"}}
+	base := Context{"settings": settings, "tags": tags, "items": items}
+
+	frozen := base.Freeze()
+	settings["theme"] = "light"
+	tags[0] = "changed"
+	items[0].(map[string]interface{})["name"] = "changed"
+
+	result, err := Render("{{ settings.theme }} {{ tags.0 }} {{ items.0.name }}", frozen.Clone())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "dark a first" {
+		t.Errorf("Expected the frozen context to keep 'dark a first', got '%s'", result)
+	}
+}
+
+func TestRenderValue(t *testing.T) {
+	err := RegisterContextFilter("testbracket", func(ctx Context, value interface{}, args ...interface{}) (interface{}, error) {
+		text, err := ctx.RenderValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return "<" + text + ">", nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer unregisterFilter("testbracket")
+
+	cases := []struct {
+		name  string
+		value interface{}
+	}{
+		{"Map", map[string]interface{}{"name": "Jane", "tags": []string{"a", "b"}}},
+		{"Time", time.Date(2024, time.March, 5, 10, 30, 0, 0, time.UTC)},
+		{"Float", 1.5},
+		{"IntSlice", []int{1, 2, 3}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewContext()
+			ctx.Set("value", tc.value)
+
+			builtin, err := Render("<{{ value }}>", ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			custom, err := Render("{{ value|testbracket }}", ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if custom != builtin {
+				t.Errorf("Expected '%s', got '%s'", builtin, custom)
+			}
+		})
+	}
+}
+
+func TestTypedGetters(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("name", "Jane")
+	ctx.Set("tags", []string{"a", "b"})
+	ctx.Set("count", 42)
+	ctx.Set("countText", " 7 ")
+	ctx.Set("whole", 3.0)
+	ctx.Set("ratio", 0.5)
+	ctx.Set("big", int64(1)<<40)
+	ctx.Set("overflow", math.Pow(2, 63))
+	ctx.Set("active", true)
+	ctx.Set("flagText", "false")
+	ctx.Set("events", make(chan int))
+
+	stringCases := []struct {
+		key      string
+		expected string
+		err      error
+	}{
+		{"name", "Jane", nil},
+		{"count", "42", nil},
+		{"tags", "[a, b]", nil},
+		{"active", "true", nil},
+		{"events", "", ErrContextValueType},
+		{"missing", "", ErrContextKeyNotFound},
+	}
+	for _, tc := range stringCases {
+		result, err := ctx.GetString(tc.key)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("GetString(%q): expected error %v, got %v", tc.key, tc.err, err)
+		}
+		if result != tc.expected {
+			t.Errorf("GetString(%q): expected '%s', got '%s'", tc.key, tc.expected, result)
+		}
+	}
+
+	intCases := []struct {
+		key      string
+		expected int
+		err      error
+	}{
+		{"count", 42, nil},
+		{"countText", 7, nil},
+		{"whole", 3, nil},
+		{"big", 1 << 40, nil},
+		{"overflow", 0, ErrContextValueType},
+		{"ratio", 0, ErrContextValueType},
+		{"name", 0, ErrContextValueType},
+		{"active", 0, ErrContextValueType},
+		{"missing", 0, ErrContextKeyNotFound},
+	}
+	for _, tc := range intCases {
+		result, err := ctx.GetInt(tc.key)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("GetInt(%q): expected error %v, got %v", tc.key, tc.err, err)
+		}
+		if result != tc.expected {
+			t.Errorf("GetInt(%q): expected %d, got %d", tc.key, tc.expected, result)
+		}
+	}
+
+	boolCases := []struct {
+		key      string
+		expected bool
+		err      error
+	}{
+		{"active", true, nil},
+		{"flagText", false, nil},
+		{"name", false, ErrContextValueType},
+		{"count", false, ErrContextValueType},
+		{"missing", false, ErrContextKeyNotFound},
+	}
+	for _, tc := range boolCases {
+		result, err := ctx.GetBool(tc.key)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("GetBool(%q): expected error %v, got %v", tc.key, tc.err, err)
+		}
+		if result != tc.expected {
+			t.Errorf("GetBool(%q): expected %t, got %t", tc.key, tc.expected, result)
+		}
+	}
+}
+
+// pathEchoResolver resolves any path to a description of it, and paths starting with "none" to nothing.
+type pathEchoResolver struct{}
+
+func (r *pathEchoResolver) Resolve(path string) (interface{}, bool) {
+	if strings.HasPrefix(path, "none") {
+		return nil, false
+	}
+	return map[string]interface{}{"path": path, "length": len(path)}, true
+}
+
+func TestResolver(t *testing.T) {
+	store := &pathEchoResolver{}
+	ctx := NewContext()
+	ctx.Set("store", store)
+	ctx.Set("nested", map[string]interface{}{"store": store})
+	ctx.Set("key", "users")
+
+	cases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"Path", "{{ store.users.42.name|index:'path' }}", "users.42.name"},
+		{"NestedResolver", "{{ nested.store.orders|index:'length' }}", "6"},
+		{"BracketKey", "{{ store[key]|index:'path' }}", "users"},
+		{"Missing", "{{ store.none ?? 'fallback' }}", "fallback"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+
+	if _, err := ctx.Get("store.none.more"); !errors.Is(err, ErrContextKeyNotFound) {
+		t.Errorf("Expected ErrContextKeyNotFound, got %v", err)
+	}
+	if value, err := ctx.Get("store"); err != nil || value != store {
+		t.Errorf("Expected the resolver itself, got %v, %v", value, err)
+	}
+}
@@ -1,8 +1,8 @@
 package template
 
-// Parse parses a template string and returns a Template instance.
-func Parse(source string) (*Template, error) {
-	parser := NewParser()
+// Parse parses a template string and returns a Template instance configured by the given options.
+func Parse(source string, opts ...Option) (*Template, error) {
+	parser := NewParser(opts...)
 	return parser.Parse(source)
 }
 
@@ -16,11 +16,29 @@ func MustExecute(tpl *Template, ctx Context) string {
 	return tpl.MustExecute(ctx)
 }
 
+// ExecuteOrEmpty renders the template with provided context for best-effort output, ignoring errors
+// and recovering from panics in filters or methods.
+func ExecuteOrEmpty(tpl *Template, ctx Context) string {
+	return tpl.ExecuteOrEmpty(ctx)
+}
+
 // Render combines parsing and executing a template with the given context for convenience.
-func Render(source string, ctx Context) (string, error) {
-	tpl, err := Parse(source)
+func Render(source string, ctx Context, opts ...Option) (string, error) {
+	tpl, err := Parse(source, opts...)
 	if err != nil {
 		return "", err
 	}
 	return Execute(tpl, ctx)
 }
+
+// RenderWith parses and executes a template with a context built from several data sources.
+// Sources are overlaid in order, so keys in later sources override the same keys in earlier ones.
+func RenderWith(source string, sources ...map[string]interface{}) (string, error) {
+	ctx := NewContext()
+	for _, data := range sources {
+		for key, value := range data {
+			ctx[key] = value
+		}
+	}
+	return Render(source, ctx)
+}
@@ -1,6 +1,8 @@
 package template
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -41,6 +43,34 @@ func TestExecuteWithErrorHandling(t *testing.T) {
 	}
 }
 
+func TestExecuteReturnsPartialOutputWithError(t *testing.T) {
+	ctx := mockUserProfileContext()
+
+	tpl, err := Parse("Report for {{ userName }}: {{ userName|split }}")
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	output, err := tpl.Execute(ctx)
+	if !errors.Is(err, ErrInsufficientArgs) {
+		t.Errorf("Expected ErrInsufficientArgs from the filter, got %v", err)
+	}
+	if output != "Report for JaneDoe: {{ userName|split }}" {
+		t.Errorf("Expected the output rendered around the failed tag, got '%s'", output)
+	}
+
+	tpl, err = Parse("Report for {{ userName }}: {{ missing }} and more", WithStrict())
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	output, err = tpl.Execute(ctx)
+	if !errors.Is(err, ErrContextKeyNotFound) {
+		t.Errorf("Expected ErrContextKeyNotFound, got %v", err)
+	}
+	if output != "Report for JaneDoe: " {
+		t.Errorf("Expected the output rendered before execution stopped, got '%s'", output)
+	}
+}
+
 func TestMustExecuteIgnoresError(t *testing.T) {
 	ctx := mockUserProfileContext()
 	// Similar template to above, which will encounter an error due to a non-existent variable.
@@ -63,6 +93,45 @@ func TestMustExecuteIgnoresError(t *testing.T) {
 	}
 }
 
+func TestExecuteOrEmptyReturnsPrefixWhenExecutionStops(t *testing.T) {
+	ctx := mockUserProfileContext()
+	ctx.Set("events", make(chan int))
+
+	err := RegisterFilter("testpanic", func(value interface{}, args ...string) (interface{}, error) {
+		panic("filter failed")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer unregisterFilter("testpanic")
+
+	cases := []struct {
+		name     string
+		template string
+		opts     []Option
+		expected string
+	}{
+		{"StrictMissing", "Hello, {{ userName }}! {{ missing }} never {{ userName }}", []Option{WithStrict()}, "Hello, JaneDoe! "},
+		{"FailsAtStart", "{{ missing }} never", []Option{WithMissingBehavior(MissingError)}, ""},
+		{"FilterError", "Hi {{ userName }} {{ userName|split }}", nil, "Hi JaneDoe {{ userName|split }}"},
+		{"FilterPanic", "Hi {{ userName }} {{ userName|testpanic }} never", nil, "Hi JaneDoe "},
+		{"PanicAtStart", "{{ userName|testpanic }} never", nil, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tpl, err := Parse(tc.template, tc.opts...)
+			if err != nil {
+				t.Fatalf("Failed to parse template: %v", err)
+			}
+			output := tpl.ExecuteOrEmpty(ctx)
+			if output != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, output)
+			}
+		})
+	}
+}
+
 func TestNestedVariableRetrieval(t *testing.T) {
 	ctx := mockUserProfileContext()
 	parser := NewParser()
@@ -427,3 +496,585 @@ func TestFiltersWithNumericArguments(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterThenSizePipeline(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("products", []map[string]interface{}{
+		{"name": "Laptop", "featured": true},
+		{"name": "Mouse", "featured": false},
+		{"name": "Monitor", "featured": true},
+		{"name": "Keyboard", "featured": true},
+		{"name": "Webcam", "featured": true},
+	})
+
+	tmpl, err := Parse(`Featured: {{ products|filter:"featured","true"|size }}`)
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	result, err := tmpl.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Failed to execute template: %v", err)
+	}
+	if result != "Featured: 4" {
+		t.Errorf("Expected 'Featured: 4', but got '%s'", result)
+	}
+
+	// The pipeline yields a plain int, so its result can be compared numerically.
+	var pipeline []Filter
+	tmpl.Walk(func(node *Node) bool {
+		if node.Type == NodeVariable && node.Variable == "products" {
+			pipeline = node.Filters
+		}
+		return true
+	})
+	if len(pipeline) == 0 {
+		t.Fatalf("Expected a products variable with filters in the template")
+	}
+	count, err := ApplyFilters(ctx["products"], pipeline, ctx)
+	if err != nil {
+		t.Fatalf("Failed to apply filters: %v", err)
+	}
+	if n, ok := count.(int); !ok || n <= 3 {
+		t.Errorf("Expected an int greater than 3, got %#v", count)
+	}
+}
+
+func TestCoalesceOperator(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("name", "Alice")
+	ctx.Set("empty", "")
+	ctx.Set("tags", []string{})
+	ctx.Set("zero", 0)
+	ctx.Set("nickname", "ally")
+
+	cases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"FirstTwoEmpty", `{{ missing ?? empty ?? "none" }}`, "none"},
+		{"EmptyCollectionSkipped", `{{ tags ?? missing ?? 'no tags' }}`, "no tags"},
+		{"FallbackVariable", `{{ missing ?? empty ?? name }}`, "Alice"},
+		{"StopsAtFirstValue", `{{ name ?? missing|unknownFilter }}`, "Alice"},
+		{"ZeroIsNotEmpty", `{{ zero ?? "none" }}`, "0"},
+		{"FilteredOperands", `{{ empty|upper ?? nickname|upper ?? "none" }}`, "ALLY"},
+		{"AllEmpty", `[{{ missing ?? empty }}]`, "[]"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestLiteralOutput(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"Integer", "{{ 42 }}", "42"},
+		{"NegativeInteger", "{{ -7 }}", "-7"},
+		{"Float", "{{ 3.14 }}", "3.14"},
+		{"BooleanTrue", "{{ true }}", "true"},
+		{"BooleanFalse", "{{ false }}", "false"},
+		{"DoubleQuotedString", `{{ "hi" }}`, "hi"},
+		{"SingleQuotedString", "{{ 'hi' }}", "hi"},
+		{"LiteralWithFilter", "{{ 41|plus:1 }}", "42"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, NewContext())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestContextKeysShadowLiterals(t *testing.T) {
+	ctx := NewContext()
+	ctx["2024"] = "leap year"
+	ctx["true"] = "yes"
+
+	result, err := Render("{{ 2024 }} {{ true }} {{ 2025 }} {{ false }}", ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "leap year yes 2025 false" {
+		t.Errorf("Expected context keys to take precedence over literals, got '%s'", result)
+	}
+}
+
+func TestConcatOperator(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("firstName", "Jane")
+	ctx.Set("lastName", "doe")
+	ctx.Set("age", 29)
+	ctx.Set("empty", "")
+
+	cases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"VariableLiteralVariable", `{{ firstName ~ " " ~ lastName }}`, "Jane doe"},
+		{"FilteredOperand", `{{ firstName ~ " " ~ lastName|upper }}`, "Jane DOE"},
+		{"NumberOperand", `{{ "Age: " ~ age }}`, "Age: 29"},
+		{"BindsTighterThanCoalesce", `{{ empty ?? firstName ~ "!" }}`, "Jane!"},
+		{"MissingOperand", `{{ firstName ~ missing }}`, "{{ firstName ~ missing }}"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, _ := Render(tc.template, ctx)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBracketAccess(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("data", map[string]interface{}{
+		"a.b":   "dotted",
+		"plain": map[string]interface{}{"value": "nested"},
+	})
+	ctx.Set("revenue", map[string]interface{}{"2022": 1500})
+	ctx.Set("year", "2022")
+	ctx.Set("key", "a.b")
+	ctx.Set("items", []string{"zero", "one"})
+
+	cases := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"DottedKeyLiteral", `{{ data["a.b"] }}`, "dotted"},
+		{"SingleQuotedKey", `{{ data['a.b'] }}`, "dotted"},
+		{"KeyFromVariable", `{{ data[key] }}`, "dotted"},
+		{"NumericKeyFromVariable", `{{ revenue[year] }}`, "1500"},
+		{"FollowedByDotPath", `{{ data["plain"].value }}`, "nested"},
+		{"SliceIndex", `{{ items[1] }}`, "one"},
+		{"WithFilter", `{{ data["a.b"]|upper }}`, "DOTTED"},
+		{"MissingKey", `{{ data["x.y"] }}`, `{{ data["x.y"] }}`},
+		{"MissingKeyVariable", `{{ data[nothing] }}`, `{{ data[nothing] }}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, _ := Render(tc.template, ctx)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestMissingBehavior(t *testing.T) {
+	cases := []struct {
+		name        string
+		behavior    MissingBehavior
+		expected    string
+		expectError bool
+	}{
+		{"KeepRaw", MissingKeepRaw, "Hello, {{ missing }}! Bye, JaneDoe.", true},
+		{"Empty", MissingEmpty, "Hello, ! Bye, JaneDoe.", false},
+		{"Error", MissingError, "Hello, ", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewParser()
+			parser.SetMissingBehavior(tc.behavior)
+			tpl, err := parser.Parse("Hello, {{ missing }}! Bye, {{ userName }}.")
+			if err != nil {
+				t.Fatalf("Failed to parse template: %v", err)
+			}
+
+			result, err := tpl.Execute(mockUserProfileContext())
+			if tc.expectError && !errors.Is(err, ErrContextKeyNotFound) {
+				t.Errorf("Expected ErrContextKeyNotFound, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnrenderableBehavior(t *testing.T) {
+	cases := []struct {
+		name        string
+		behavior    UnrenderableBehavior
+		expected    string
+		expectError bool
+	}{
+		{"KeepRaw", UnrenderableKeepRaw, "Before {{ events }} after JaneDoe.", false},
+		{"Placeholder", UnrenderablePlaceholder, "Before [unavailable] after JaneDoe.", false},
+		{"Error", UnrenderableError, "Before  after JaneDoe.", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewParser()
+			parser.SetUnrenderableBehavior(tc.behavior)
+			parser.SetUnrenderablePlaceholder("[unavailable]")
+			tpl, err := parser.Parse("Before {{ events }} after {{ userName }}.")
+			if err != nil {
+				t.Fatalf("Failed to parse template: %v", err)
+			}
+
+			ctx := mockUserProfileContext()
+			ctx.Set("events", make(chan int))
+			result, err := tpl.Execute(ctx)
+			if tc.expectError && !errors.Is(err, ErrUnrenderableValue) {
+				t.Errorf("Expected ErrUnrenderableValue, got %v", err)
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestRenderOptions(t *testing.T) {
+	ctx := mockUserProfileContext()
+	ctx.Set("events", make(chan int))
+
+	cases := []struct {
+		name        string
+		template    string
+		opts        []Option
+		expected    string
+		expectedErr error
+	}{
+		{"Defaults", "Hi {{ missing }}", nil, "Hi {{ missing }}", ErrContextKeyNotFound},
+		{"MissingEmpty", "Hi {{ missing }}{{ userName }}", []Option{WithMissingBehavior(MissingEmpty)}, "Hi JaneDoe", nil},
+		{"MissingEmptyFilterArgument", "Hi {{ userName|truncate:limit }}!", []Option{WithMissingBehavior(MissingEmpty)}, "Hi !", ErrFilterArgNotFound},
+		{"Placeholder", "[{{ events }}]", []Option{WithUnrenderablePlaceholder("?")}, "[?]", nil},
+		{"StrictMissing", "Hi {{ missing }}, {{ userName }}", []Option{WithStrict()}, "Hi ", ErrContextKeyNotFound},
+		{"StrictMissingWithDefault", "Hi {{ missing|default:'x' }}", []Option{WithStrict()}, "Hi ", ErrContextKeyNotFound},
+		{"StrictMissingWithDefaultIfNone", "Hi {{ missing|default_if_none:'x' }}", []Option{WithStrict()}, "Hi x", nil},
+		{"StrictUnknownFilter", "Hi {{ userName|nosuchfilter }}", []Option{WithStrict()}, "", ErrFilterNotFound},
+		{"StrictUnrenderable", "[{{ events }}]", []Option{WithStrict()}, "[]", ErrUnrenderableValue},
+		{"StrictWithPlaceholder", "[{{ events }}] {{ userName|upper }}", []Option{WithStrict(), WithUnrenderablePlaceholder("?")}, "[?] JANEDOE", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, ctx, tc.opts...)
+			if tc.expectedErr == nil && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tc.expectedErr != nil && !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestOnMissingHandler(t *testing.T) {
+	type report struct {
+		name         string
+		line, column int
+	}
+
+	for _, behavior := range []MissingBehavior{MissingKeepRaw, MissingEmpty} {
+		var reports []report
+		parser := NewParser()
+		parser.SetMissingBehavior(behavior)
+		parser.OnMissing(func(name string, line, column int) {
+			reports = append(reports, report{name, line, column})
+		})
+		tpl, err := parser.Parse("Hi {{ userName }} {{ nickname }}\n{{ nickname ?? userName }} {{ first ~ last }}")
+		if err != nil {
+			t.Fatalf("Failed to parse template: %v", err)
+		}
+
+		ctx := mockUserProfileContext()
+		ctx.Set("last", "Doe")
+		_, _ = tpl.Execute(ctx)
+
+		expected := []report{{"nickname", 1, 19}, {"first", 2, 28}}
+		if !reflect.DeepEqual(reports, expected) {
+			t.Errorf("Behavior %d: expected %v, got %v", behavior, expected, reports)
+		}
+	}
+
+	var reports []report
+	tpl, err := Parse(`a \{{ x }} \{% y\\{{ nickname }}`, WithDelimiterEscapes(), WithOnMissing(func(name string, line, column int) {
+		reports = append(reports, report{name, line, column})
+	}))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	_, _ = tpl.Execute(mockUserProfileContext())
+
+	expected := []report{{"nickname", 1, 19}}
+	if !reflect.DeepEqual(reports, expected) {
+		t.Errorf("Escaped delimiters: expected %v, got %v", expected, reports)
+	}
+}
+
+// callCounter counts calls to its Next method.
+type callCounter struct {
+	calls int
+}
+
+func (c *callCounter) Next() int {
+	c.calls++
+	return c.calls
+}
+
+func TestOnMissingHandlerResolvesOnce(t *testing.T) {
+	var names []string
+	tpl, err := Parse("{{ counter.Next|plus:limit }}", WithOnMissing(func(name string, line, column int) {
+		names = append(names, name)
+	}))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	counter := &callCounter{}
+	_, _ = tpl.Execute(Context{"counter": counter})
+	if counter.calls != 1 {
+		t.Errorf("Expected the method to be called once, got %d calls", counter.calls)
+	}
+	if !reflect.DeepEqual(names, []string{"limit"}) {
+		t.Errorf("Expected 'limit' to be reported, got %v", names)
+	}
+}
+
+type methodTestUser struct {
+	First string
+	Last  string
+	email string
+}
+
+func (u methodTestUser) FullName() string {
+	return u.First + " " + u.Last
+}
+
+func (u *methodTestUser) Initials() string {
+	return u.First[:1] + u.Last[:1]
+}
+
+func (u methodTestUser) Email() (string, error) {
+	if u.email == "" {
+		return "", errors.New("no email on file")
+	}
+	return u.email, nil
+}
+
+func (u methodTestUser) Verify() error {
+	if u.email == "" {
+		return errors.New("no email on file")
+	}
+	return nil
+}
+
+func (u methodTestUser) Greeting(name string) string {
+	return "Hi " + name
+}
+
+func TestMethodCalls(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("user", methodTestUser{First: "Jane", Last: "Doe", email: "jane@example.com"})
+	ctx.Set("guest", &methodTestUser{First: "John", Last: "Roe"})
+
+	cases := []struct {
+		name        string
+		template    string
+		opts        []Option
+		expected    string
+		expectedErr error
+	}{
+		{"Field", "{{ user.First }}", nil, "Jane", nil},
+		{"Method", "{{ user.FullName|upper }}", nil, "JANE DOE", nil},
+		{"PointerReceiverOnValue", "{{ user.Initials }}", nil, "JD", nil},
+		{"MethodOnPointer", "{{ guest.FullName }} {{ guest.Initials }}", nil, "John Roe JR", nil},
+		{"BracketMethod", "{{ user['FullName'] }}", nil, "Jane Doe", nil},
+		{"ValueAndNilError", "{{ user.Email }}", nil, "jane@example.com", nil},
+		{"ValueAndError", "{{ guest.Email }}", nil, "{{ guest.Email }}", ErrContextMethodCall},
+		{"ValueAndErrorStrict", "{{ guest.Email }}", []Option{WithStrict()}, "{{ guest.Email }}", ErrContextMethodCall},
+		{"ErrorOnlyNil", "{{ user.Verify|default_if_none:'ok' }}", nil, "ok", nil},
+		{"ErrorOnly", "{{ guest.Verify }}", nil, "{{ guest.Verify }}", ErrContextMethodCall},
+		{"MethodCallsDisabled", "{{ user.First }} {{ user.FullName }}", []Option{WithoutMethodCalls()}, "Jane {{ user.FullName }}", ErrContextInvalidKeyType},
+		{"MethodCallsDisabledBracket", "{{ user['FullName'] }}", []Option{WithoutMethodCalls()}, "{{ user['FullName'] }}", ErrContextKeyNotFound},
+		{"MethodWithArguments", "{{ user.Greeting }}", nil, "{{ user.Greeting }}", ErrContextInvalidKeyType},
+		{"ContextMethodsHidden", "{{ Locale }}", nil, "{{ Locale }}", ErrContextKeyNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.template, ctx, tc.opts...)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestComplexRender(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("settings", map[string]interface{}{"theme": "dark", "size": 2})
+	ctx.Set("tags", []string{"a", "b"})
+
+	cases := []struct {
+		name        string
+		mode        ComplexRender
+		expected    string
+		expectedErr error
+	}{
+		{"JSON", ComplexRenderJSON, "{\n  \"size\": 2,\n  \"theme\": \"dark\"\n} [a, b] cfg: {\n  \"size\": 2,\n  \"theme\": \"dark\"\n}", nil},
+		{"GoSyntax", ComplexRenderGoSyntax, "map[size:2 theme:dark] [a, b] cfg: map[size:2 theme:dark]", nil},
+		{"Error", ComplexRenderError, "{{ settings }} [a, b] {{ 'cfg: ' ~ settings }}", ErrComplexValue},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render("{{ settings }} {{ tags }} {{ 'cfg: ' ~ settings }}", ctx, WithComplexRender(tc.mode))
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestTextTransform(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("name", "*jane*")
+
+	emphasize := func(text string) string {
+		return strings.ReplaceAll(text, "**", "<b>")
+	}
+
+	cases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"TextAroundVariable", "**Hi** {{ name }}, **welcome**", "<b>Hi<b> *jane*, <b>welcome<b>"},
+		{"TransformedValueIsLeftAlone", "{{ name ~ '*' }}", "*jane**"},
+		{"StaticTemplate", "**static**", "<b>static<b>"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.source, ctx, WithTextTransform(emphasize))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestTextTransformRunsOnceAtParseTime(t *testing.T) {
+	var fragments []string
+	record := func(text string) string {
+		fragments = append(fragments, text)
+		return strings.ToUpper(text)
+	}
+
+	tpl, err := Parse("**hi {{ name }}**", WithTextTransform(record))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	ctx := NewContext()
+	ctx.Set("name", "jane")
+	for i := 0; i < 3; i++ {
+		result, err := tpl.Execute(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result != "**HI jane**" {
+			t.Errorf("Expected '**HI jane**', got '%s'", result)
+		}
+	}
+
+	expected := []string{"**hi ", "**"}
+	if !reflect.DeepEqual(fragments, expected) {
+		t.Errorf("Expected the transform to see %q once, got %q", expected, fragments)
+	}
+	if tpl.String() != "**hi {{ name }}**" {
+		t.Errorf("Expected String to return the source, got '%s'", tpl.String())
+	}
+}
+
+func TestTextTransformAfterCloneAndEdit(t *testing.T) {
+	tpl, err := Parse("**hi** {{ name }}", WithTextTransform(strings.ToUpper))
+	if err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	ctx := NewContext()
+	ctx.Set("name", "jane")
+
+	clone := tpl.Clone()
+	clone.Nodes = append([]*Node{{Type: NodeText, Text: "> "}}, clone.Nodes...)
+	clone.Nodes[1].Text = "**bye** "
+
+	result, err := clone.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "> **BYE** jane" {
+		t.Errorf("Expected '> **BYE** jane', got '%s'", result)
+	}
+
+	result, err = tpl.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "**HI** jane" {
+		t.Errorf("Expected the original to render '**HI** jane', got '%s'", result)
+	}
+}
+
+func TestRenderWithMultipleSources(t *testing.T) {
+	globals := map[string]interface{}{"site": "Example", "title": "Default Title", "lang": "en"}
+	request := map[string]interface{}{"lang": "fr", "path": "/about"}
+	page := map[string]interface{}{"title": "About Us"}
+
+	result, err := RenderWith("{{ site }} | {{ title }} | {{ lang }} | {{ path }}", globals, request, page)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "Example | About Us | fr | /about"
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+	if globals["title"] != "Default Title" {
+		t.Errorf("Expected sources to be left unchanged, got title '%v'", globals["title"])
+	}
+}
@@ -12,6 +12,15 @@ var (
 	// ErrContextIndexOutOfRange is returned when an index is out of range in the context.
 	ErrContextIndexOutOfRange = errors.New("index out of range in context")
 
+	// ErrContextValueType is returned when a context value cannot be converted to the requested type.
+	ErrContextValueType = errors.New("context value has an unexpected type")
+
+	// ErrContextMethodCall is returned when a method called while resolving a path returns an error.
+	ErrContextMethodCall = errors.New("method call failed")
+
+	// ErrContextReadOnly is returned when writing to a frozen context.
+	ErrContextReadOnly = errors.New("context is read-only")
+
 	// ErrFilterNotFound indicates that the requested filter was not found in the global registry.
 	ErrFilterNotFound = errors.New("filter not found")
 
@@ -42,9 +51,26 @@ var (
 	// ErrInvalidFilterName is returned when a filter name does not meet the required criteria.
 	ErrInvalidFilterName = errors.New("invalid filter name")
 
+	// ErrFilterArgNotFound is returned along with ErrContextKeyNotFound when a variable used as a filter argument
+	// is not found in the context.
+	ErrFilterArgNotFound = errors.New("filter argument not found")
+
 	// ErrUnknownFilterArgumentType is returned when a filter argument type is unknown.
 	ErrUnknownFilterArgumentType = errors.New("unknown argument type")
 
+	// ErrTranslationDepth is returned when translated messages render each other more deeply than allowed,
+	// for example a message that translates its own key.
+	ErrTranslationDepth = errors.New("translated messages are nested too deeply")
+
+	// ErrIncomparableValues indicates that two values of mismatched types cannot be ordered.
+	ErrIncomparableValues = errors.New("values cannot be compared")
+
+	// ErrUnrenderableValue is returned when a value, such as a channel or a function, cannot be rendered as text.
+	ErrUnrenderableValue = errors.New("value cannot be rendered as text")
+
+	// ErrComplexValue is returned when a map, slice, or struct is rendered under ComplexRenderError.
+	ErrComplexValue = errors.New("complex value cannot be rendered")
+
 	// ErrUnknownNodeType is returned when an unexpected node type is encountered.
 	ErrUnknownNodeType = errors.New("unknown node type")
 )
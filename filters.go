@@ -3,12 +3,28 @@ package template
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 )
 
 // FilterFunc represents the signature of functions that can be applied as filters.
 type FilterFunc func(interface{}, ...string) (interface{}, error)
 
-var filters = make(map[string]FilterFunc)
+// ContextFilterFunc represents filters that receive the rendering context, for example to read its locale,
+// and their arguments as resolved values, so variables holding maps, slices, or structs reach the filter unchanged.
+type ContextFilterFunc func(Context, interface{}, ...interface{}) (interface{}, error)
+
+// templateFilterFunc is the form every registered filter is adapted to. Besides the context, it receives
+// the executing template, so built-in filters that render text can use the template's options.
+type templateFilterFunc func(*Template, Context, interface{}, ...interface{}) (interface{}, error)
+
+// registeredFilter is an entry in the global filter registry.
+type registeredFilter struct {
+	fn templateFilterFunc
+	// acceptsMissing marks filters that run with a nil input when their variable is missing from the context.
+	acceptsMissing bool
+}
+
+var filters = make(map[string]registeredFilter)
 
 // Global variable for validating filter names
 var validFilterNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
@@ -18,40 +34,102 @@ func RegisterFilter(name string, fn FilterFunc) error {
 	if !validFilterNameRegex.MatchString(name) {
 		return fmt.Errorf("%w: '%s'", ErrInvalidFilterName, name)
 	}
-	filters[name] = fn
+	filters[name] = registeredFilter{
+		fn: func(_ *Template, _ Context, value interface{}, args ...interface{}) (interface{}, error) {
+			return fn(value, stringifyFilterArgs(args)...)
+		},
+	}
 	return nil
 }
 
+// RegisterContextFilter adds a filter that receives the rendering context and resolved argument values
+// to the global registry with name validation.
+func RegisterContextFilter(name string, fn ContextFilterFunc) error {
+	if !validFilterNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidFilterName, name)
+	}
+	filters[name] = registeredFilter{
+		fn: func(_ *Template, ctx Context, value interface{}, args ...interface{}) (interface{}, error) {
+			return fn(ctx, value, args...)
+		},
+	}
+	return nil
+}
+
+// registerTemplateFilter adds a built-in filter that receives the executing template to the global registry.
+func registerTemplateFilter(name string, fn templateFilterFunc) error {
+	if !validFilterNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidFilterName, name)
+	}
+	filters[name] = registeredFilter{fn: fn}
+	return nil
+}
+
+// acceptMissingInput marks a registered filter as running with a nil input when its variable is missing.
+func acceptMissingInput(name string) {
+	if registered, ok := filters[name]; ok {
+		registered.acceptsMissing = true
+		filters[name] = registered
+	}
+}
+
+// missingInputFilter returns the index of the first filter in the chain that accepts a missing input, or -1.
+func missingInputFilter(fs []Filter) int {
+	for i, f := range fs {
+		if filters[f.Name].acceptsMissing {
+			return i
+		}
+	}
+	return -1
+}
+
+// unregisterFilter removes a filter from the global registry.
+func unregisterFilter(name string) {
+	delete(filters, name)
+}
+
+// filterRegistered reports whether a filter is registered under the name.
+func filterRegistered(name string) bool {
+	_, exists := filters[name]
+	return exists
+}
+
 // ApplyFilters executes a series of filters on a value within a context, supporting variable arguments.
+// Filters run with the options of a template parsed with no options.
 func ApplyFilters(value interface{}, fs []Filter, ctx Context) (interface{}, error) {
+	return NewTemplate().applyFilters(value, fs, ctx)
+}
+
+// applyFilters executes a series of filters on a value on behalf of the template.
+func (t *Template) applyFilters(value interface{}, fs []Filter, ctx Context) (interface{}, error) {
 	var err error
 	for _, f := range fs {
-		fn, exists := filters[f.Name]
-		if !exists {
+		registered, ok := filters[f.Name]
+		if !ok {
 			return value, fmt.Errorf("%w: filter '%s' not found", ErrFilterNotFound, f.Name)
 		}
 
 		// Prepare arguments by checking their types and extracting values for VariableArg.
-		args := make([]string, len(f.Args))
+		values := make([]interface{}, len(f.Args))
 		for i, arg := range f.Args {
 			switch arg := arg.(type) {
-			case StringArg:
-				args[i] = arg.Value().(string)
-			case NumberArg:
-				args[i] = fmt.Sprint(arg.Value())
+			case StringArg, NumberArg, BoolArg:
+				values[i] = arg.Value()
 			case VariableArg:
-				val, err := ctx.Get(arg.Value().(string))
+				val, err := ctx.get(arg.Value().(string), !t.disableMethodCalls)
 				if err != nil {
-					return value, fmt.Errorf("%w: variable '%s' not found in context", ErrContextKeyNotFound, arg.Value().(string))
+					name := arg.Value().(string)
+					missing := fmt.Errorf("%w: %w: variable '%s' for filter '%s'", ErrContextKeyNotFound, ErrFilterArgNotFound, name, f.Name)
+					return value, &missingReferenceError{template: t, name: name, err: missing}
 				}
-				args[i] = fmt.Sprint(val)
+				values[i] = val
 			default:
 				return value, fmt.Errorf("%w for filter '%s'", ErrUnknownFilterArgumentType, f.Name)
 			}
 		}
 
 		// Apply each filter with the prepared arguments.
-		value, err = fn(value, args...)
+		value, err = registered.fn(t, ctx, value, values...)
 		if err != nil {
 			return value, fmt.Errorf("error applying '%s' filter: %w", f.Name, err)
 		}
@@ -59,6 +137,22 @@ func ApplyFilters(value interface{}, fs []Filter, ctx Context) (interface{}, err
 	return value, nil
 }
 
+// stringifyFilterArgs converts resolved argument values into the string form expected by FilterFunc.
+func stringifyFilterArgs(values []interface{}) []string {
+	args := make([]string, len(values))
+	for i, val := range values {
+		switch v := val.(type) {
+		case string:
+			args[i] = v
+		case bool:
+			args[i] = strconv.FormatBool(v)
+		default:
+			args[i] = fmt.Sprint(v)
+		}
+	}
+	return args
+}
+
 // Filter defines a transformation to apply to a template variable.
 type Filter struct {
 	Name string
@@ -97,6 +191,19 @@ func (a NumberArg) Type() string {
 	return "number"
 }
 
+// BoolArg holds a boolean literal argument.
+type BoolArg struct {
+	val bool
+}
+
+func (a BoolArg) Value() interface{} {
+	return a.val
+}
+
+func (a BoolArg) Type() string {
+	return "bool"
+}
+
 // VariableArg holds a variable argument.
 type VariableArg struct {
 	name string
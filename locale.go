@@ -0,0 +1,182 @@
+package template
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLocale names the locale used when a context does not set one.
+const DefaultLocale = "en-US"
+
+// LocaleProvider formats numbers, amounts of money, and dates for a locale.
+type LocaleProvider interface {
+	// FormatNumber formats a number with the given number of decimal places.
+	FormatNumber(number float64, decimals int) string
+	// FormatCurrency formats an amount of money. An empty symbol selects the locale's own currency symbol.
+	FormatCurrency(amount float64, symbol string) string
+	// FormatDate formats a date in the locale's default style.
+	FormatDate(t time.Time) string
+}
+
+// defaultLocaleProvider is the built-in provider for DefaultLocale.
+var defaultLocaleProvider = &BasicLocale{
+	DecimalSeparator: ".",
+	GroupSeparator:   ",",
+	CurrencySymbol:   "$",
+	DateLayout:       "January 2, 2006",
+}
+
+var locales = map[string]LocaleProvider{
+	DefaultLocale: defaultLocaleProvider,
+}
+
+// RegisterLocale adds a locale provider to the global registry, replacing any provider with the same name.
+func RegisterLocale(name string, provider LocaleProvider) {
+	locales[name] = provider
+}
+
+// WithLocale returns a copy of the template that renders with the named locale, sharing its nodes.
+// Use it to render one parsed template in several locales.
+func (t *Template) WithLocale(name string) *Template {
+	localized := *t
+	localized.locale = name
+	return &localized
+}
+
+// Locale returns the provider for the template's locale, falling back to DefaultLocale when the
+// template sets no locale or names one that is not registered.
+func (t *Template) Locale() LocaleProvider {
+	if provider, exists := locales[t.locale]; exists {
+		return provider
+	}
+	return locales[DefaultLocale]
+}
+
+// localeName returns the locale name set on the template, or DefaultLocale when none is set.
+func (t *Template) localeName() string {
+	if t.locale != "" {
+		return t.locale
+	}
+	return DefaultLocale
+}
+
+// BasicLocale is a LocaleProvider built from separators, a currency symbol, a date layout, and month names.
+type BasicLocale struct {
+	DecimalSeparator string
+	GroupSeparator   string
+	CurrencySymbol   string
+	// CurrencySuffix places the currency symbol after the amount, separated by a space.
+	CurrencySuffix bool
+	// DateLayout is a time.Format layout for dates.
+	DateLayout string
+	// MonthNames, when set, replace the English full month names produced by DateLayout.
+	MonthNames [12]string
+}
+
+// FormatNumber formats a number with grouped thousands and the given number of decimal places.
+func (l *BasicLocale) FormatNumber(number float64, decimals int) string {
+	formatted := strconv.FormatFloat(math.Abs(number), 'f', decimals, 64)
+	integer, fraction, hasFraction := strings.Cut(formatted, ".")
+
+	var builder strings.Builder
+	if number < 0 && strings.Trim(formatted, "0.") != "" {
+		builder.WriteByte('-')
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			builder.WriteString(l.GroupSeparator)
+		}
+		builder.WriteRune(digit)
+	}
+	if hasFraction {
+		builder.WriteString(l.DecimalSeparator)
+		builder.WriteString(fraction)
+	}
+	return builder.String()
+}
+
+// FormatCurrency formats an amount with two decimal places and a currency symbol.
+func (l *BasicLocale) FormatCurrency(amount float64, symbol string) string {
+	if symbol == "" {
+		symbol = l.CurrencySymbol
+	}
+	number := l.FormatNumber(math.Abs(amount), 2)
+	sign := ""
+	if amount < 0 && number != l.FormatNumber(0, 2) {
+		sign = "-"
+	}
+	if l.CurrencySuffix {
+		return sign + number + " " + symbol
+	}
+	return sign + symbol + number
+}
+
+// FormatDate formats a date with DateLayout, replacing English month names when MonthNames is set.
+func (l *BasicLocale) FormatDate(t time.Time) string {
+	formatted := t.Format(l.DateLayout)
+	if name := l.MonthNames[t.Month()-1]; name != "" {
+		formatted = strings.ReplaceAll(formatted, t.Month().String(), name)
+	}
+	return formatted
+}
+
+// Translator looks up the message for a key in a locale's catalog.
+type Translator interface {
+	// Translate returns the message for key in locale, and whether it was found.
+	Translate(locale, key string) (string, bool)
+}
+
+// MapTranslator is a Translator backed by one map of messages per locale name.
+type MapTranslator map[string]map[string]string
+
+// Translate returns the message for key in locale, and whether it was found.
+func (m MapTranslator) Translate(locale, key string) (string, bool) {
+	message, ok := m[locale][key]
+	return message, ok
+}
+
+var translator Translator
+
+// SetTranslator sets the global Translator used by the t filter. Passing nil removes it.
+func SetTranslator(tr Translator) {
+	translator = tr
+}
+
+// PluralRule selects which plural form of a message to use for a count.
+type PluralRule interface {
+	// PluralForm returns the zero-based index of the form to use for count.
+	PluralForm(count float64) int
+}
+
+// PluralRuleFunc adapts an ordinary function to the PluralRule interface.
+type PluralRuleFunc func(count float64) int
+
+// PluralForm calls f(count).
+func (f PluralRuleFunc) PluralForm(count float64) int {
+	return f(count)
+}
+
+// englishPluralRule uses the first form for exactly one and the second form otherwise.
+var englishPluralRule = PluralRuleFunc(func(count float64) int {
+	if count == 1 {
+		return 0
+	}
+	return 1
+})
+
+var pluralRules = map[string]PluralRule{}
+
+// RegisterPluralRule sets the plural rule for a locale. Locales without a rule use the English rule.
+func RegisterPluralRule(locale string, rule PluralRule) {
+	pluralRules[locale] = rule
+}
+
+// pluralRule returns the plural rule for a locale.
+func pluralRule(locale string) PluralRule {
+	if rule, ok := pluralRules[locale]; ok {
+		return rule
+	}
+	return englishPluralRule
+}
@@ -0,0 +1,101 @@
+package template
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleAwareFilters(t *testing.T) {
+	RegisterLocale("de-DE", &BasicLocale{
+		DecimalSeparator: ",",
+		GroupSeparator:   ".",
+		CurrencySymbol:   "€",
+		CurrencySuffix:   true,
+		DateLayout:       "2. January 2006",
+		MonthNames: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	})
+
+	source := "{{ amount|currency }} | {{ refund|currency }} | {{ day|date }}"
+	data := map[string]interface{}{
+		"amount": 1234567.5,
+		"refund": -42,
+		"day":    time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC),
+	}
+
+	cases := []struct {
+		name     string
+		locale   string
+		expected string
+	}{
+		{"EnglishUS", "en-US", "$1,234,567.50 | -$42.00 | 2024-03-05 10:00:00"},
+		{"German", "de-DE", "1.234.567,50 € | -42,00 € | 5. März 2024"},
+		{"UnknownFallsBackToDefault", "xx-XX", "$1,234,567.50 | -$42.00 | 2024-03-05 10:00:00"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := NewContext()
+			for key, value := range data {
+				ctx.Set(key, value)
+			}
+
+			result, err := Render(source, ctx, WithLocale(tc.locale))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestLocaleIsNotTemplateData(t *testing.T) {
+	RegisterLocale("de-AT", &BasicLocale{DecimalSeparator: ",", GroupSeparator: ".", CurrencySymbol: "€", CurrencySuffix: true})
+
+	tpl, err := Parse("{{ amount|currency }}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := Context{"amount": 1.5}
+
+	result, err := tpl.WithLocale("de-AT").Execute(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "1,50 €" {
+		t.Errorf("Expected '1,50 €', got '%s'", result)
+	}
+	if len(ctx) != 1 {
+		t.Errorf("Expected the locale to leave the context data alone, got %v", ctx)
+	}
+	if result, _ := tpl.Execute(ctx); result != "$1.50" {
+		t.Errorf("Expected the parsed template to keep the default locale, got '%s'", result)
+	}
+
+	ctx = NewContext()
+	ctx.Set("_locale", "de-DE")
+	ctx.Set("amount", 1.5)
+	result, err = Render("{{ _locale }} {{ amount|currency }}", ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "de-DE $1.50" {
+		t.Errorf("Expected a _locale variable to be ordinary data, got '%s'", result)
+	}
+}
+
+func TestLocaleDefaults(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("amount", 9.999)
+	ctx.Set("small", -0.001)
+
+	result, err := Render(`{{ amount|currency }} {{ amount|currency:"£" }} {{ small|currency }}`, ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "$10.00 £10.00 $0.00" {
+		t.Errorf("Expected '$10.00 £10.00 $0.00', got '%s'", result)
+	}
+}
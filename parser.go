@@ -1,54 +1,342 @@
 package template
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
-// Regular expression to identify variables.
-var variableRegex = regexp.MustCompile(`{{\s*([\w\.]+)((?:\s*\|\s*[\w\:\,]+(?:\s*:\s*[^}]+)?)*)\s*}}`)
+// Regular expression fragments describing a single operand and its filter chain.
+// Quoted strings are matched whole, so braces inside them never end a variable.
+const (
+	operandPattern = `(?:-?\d+(?:\.\d+)?|[\w\.]+(?:\[\s*(?:'[^']*'|"[^"]*"|[\w\.]+)\s*\][\w\.]*)*|'[^']*'|"[^"]*")`
+	filtersPattern = `(?:\s*\|\s*[\w\:\,]+(?:\s*:\s*(?:'[^']*'|"[^"]*"|[^}'"])+)?)*`
+)
+
+// Regular expression to identify variables, optionally joined with the ?? and ~ operators.
+var variableRegex = regexp.MustCompile(`{{\s*` + operandPattern + filtersPattern +
+	`(?:\s*(?:\?\?|~)\s*` + operandPattern + filtersPattern + `)*\s*}}`)
+
+// Regular expression to validate an operand name.
+var operandRegex = regexp.MustCompile(`^` + operandPattern + `$`)
+
+// MissingBehavior controls how a variable that cannot be resolved is rendered.
+type MissingBehavior int
+
+const (
+	// MissingKeepRaw renders the original variable tag and reports the error. This is the default.
+	MissingKeepRaw MissingBehavior = iota
+	// MissingEmpty renders an empty string and reports no error, unless a variable used as a filter argument is missing.
+	MissingEmpty
+	// MissingError stops execution at the first unresolved variable and returns its error.
+	MissingError
+)
+
+// UnrenderableBehavior controls how a value that cannot be converted to text, such as a channel, is rendered.
+type UnrenderableBehavior int
+
+const (
+	// UnrenderableKeepRaw renders the original variable tag and reports no error. This is the default.
+	UnrenderableKeepRaw UnrenderableBehavior = iota
+	// UnrenderablePlaceholder renders the placeholder set with SetUnrenderablePlaceholder and reports no error.
+	UnrenderablePlaceholder
+	// UnrenderableError renders nothing for the tag and returns an error wrapping ErrUnrenderableValue.
+	UnrenderableError
+)
+
+// ComplexRender controls how maps, slices, and structs without a text form of their own are rendered.
+type ComplexRender int
+
+const (
+	// ComplexRenderJSON renders the value as indented JSON. This is the default.
+	ComplexRenderJSON ComplexRender = iota
+	// ComplexRenderGoSyntax renders the value with fmt's %v verb, e.g. map[a:1].
+	ComplexRenderGoSyntax
+	// ComplexRenderError renders the original variable tag and returns an error wrapping ErrComplexValue.
+	ComplexRenderError
+)
+
+// TextTransform rewrites the text between tags, for example converting Markdown to HTML.
+type TextTransform func(text string) string
+
+// MissingHandler is called with the name and position of each variable that cannot be resolved during execution.
+type MissingHandler func(name string, line, column int)
 
 // Parser analyzes template syntax.
-type Parser struct{}
+type Parser struct {
+	missingBehavior         MissingBehavior
+	unrenderableBehavior    UnrenderableBehavior
+	unrenderablePlaceholder string
+	onMissing               MissingHandler
+	complexRender           ComplexRender
+	textTransform           TextTransform
+	disableMethodCalls      bool
+	delimiterEscapes        bool
+	locale                  string
+	validateFilters         bool
+}
+
+// NewParser creates a Parser configured by the given options.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Option configures a Parser. Options can be passed to NewParser, Parse, and Render.
+type Option func(*Parser)
+
+// WithMissingBehavior returns an Option that calls SetMissingBehavior.
+func WithMissingBehavior(behavior MissingBehavior) Option {
+	return func(p *Parser) {
+		p.SetMissingBehavior(behavior)
+	}
+}
+
+// WithUnrenderableBehavior returns an Option that calls SetUnrenderableBehavior.
+func WithUnrenderableBehavior(behavior UnrenderableBehavior) Option {
+	return func(p *Parser) {
+		p.SetUnrenderableBehavior(behavior)
+	}
+}
+
+// WithUnrenderablePlaceholder returns an Option that renders unrenderable values as the placeholder.
+func WithUnrenderablePlaceholder(placeholder string) Option {
+	return func(p *Parser) {
+		p.SetUnrenderableBehavior(UnrenderablePlaceholder)
+		p.SetUnrenderablePlaceholder(placeholder)
+	}
+}
+
+// WithOnMissing returns an Option that calls OnMissing.
+func WithOnMissing(handler MissingHandler) Option {
+	return func(p *Parser) {
+		p.OnMissing(handler)
+	}
+}
+
+// WithComplexRender returns an Option that calls SetComplexRender.
+func WithComplexRender(mode ComplexRender) Option {
+	return func(p *Parser) {
+		p.SetComplexRender(mode)
+	}
+}
+
+// WithTextTransform returns an Option that calls SetTextTransform.
+func WithTextTransform(transform TextTransform) Option {
+	return func(p *Parser) {
+		p.SetTextTransform(transform)
+	}
+}
+
+// WithoutMethodCalls returns an Option that calls SetMethodCalls(false).
+func WithoutMethodCalls() Option {
+	return func(p *Parser) {
+		p.SetMethodCalls(false)
+	}
+}
+
+// WithDelimiterEscapes returns an Option that calls SetDelimiterEscapes(true).
+func WithDelimiterEscapes() Option {
+	return func(p *Parser) {
+		p.SetDelimiterEscapes(true)
+	}
+}
+
+// WithLocale returns an Option that calls SetLocale.
+func WithLocale(name string) Option {
+	return func(p *Parser) {
+		p.SetLocale(name)
+	}
+}
+
+// WithValidateFilters returns an Option that calls SetValidateFilters(true).
+func WithValidateFilters() Option {
+	return func(p *Parser) {
+		p.SetValidateFilters(true)
+	}
+}
+
+// WithStrict returns an Option that rejects unknown filters at parse time, stops execution at the
+// first unresolved variable, and reports unrenderable values as errors.
+func WithStrict() Option {
+	return func(p *Parser) {
+		p.SetValidateFilters(true)
+		p.SetMissingBehavior(MissingError)
+		p.SetUnrenderableBehavior(UnrenderableError)
+	}
+}
+
+// SetMissingBehavior sets how templates parsed by this parser render unresolved variables.
+func (p *Parser) SetMissingBehavior(behavior MissingBehavior) {
+	p.missingBehavior = behavior
+}
+
+// SetUnrenderableBehavior sets how templates parsed by this parser render values that cannot be converted to text.
+func (p *Parser) SetUnrenderableBehavior(behavior UnrenderableBehavior) {
+	p.unrenderableBehavior = behavior
+}
+
+// SetUnrenderablePlaceholder sets the text rendered in place of an unrenderable value under UnrenderablePlaceholder.
+func (p *Parser) SetUnrenderablePlaceholder(placeholder string) {
+	p.unrenderablePlaceholder = placeholder
+}
+
+// SetComplexRender sets how templates parsed by this parser render maps, slices, and structs that have
+// no text form of their own. Slices of strings, numbers, and booleans keep their [a, b] format.
+func (p *Parser) SetComplexRender(mode ComplexRender) {
+	p.complexRender = mode
+}
+
+// OnMissing sets a handler that templates parsed by this parser call for every unresolved variable,
+// whatever the missing behavior. Operands of ?? are not reported. A template shared between
+// goroutines calls the handler concurrently. Positions are only tracked while a handler is set.
+func (p *Parser) OnMissing(handler MissingHandler) {
+	p.onMissing = handler
+}
+
+// SetTextTransform sets a function that is applied once, when parsing, to the text of templates parsed by
+// this parser. Each run of text between tags is transformed separately, so the transform sees fragments:
+// markup that spans a tag is split across calls. Text nodes added or edited after parsing are transformed
+// when rendered. Values rendered by tags are never transformed.
+// Passing nil removes the transform.
+func (p *Parser) SetTextTransform(transform TextTransform) {
+	p.textTransform = transform
+}
+
+// SetMethodCalls sets whether templates parsed by this parser may call zero-argument methods of context
+// values, as in {{ user.FullName }}. It is on by default. Turn it off when rendering untrusted templates,
+// since any such exported method can be called, including ones with side effects.
+func (p *Parser) SetMethodCalls(enabled bool) {
+	p.disableMethodCalls = !enabled
+}
+
+// SetDelimiterEscapes enables backslash escapes in template text. A backslash before {{ or {% outputs
+// the delimiter itself instead of starting a tag, and two backslashes output one literal backslash.
+// It is off by default, so backslashes before tags, as in C:\{{ dir }}, are plain text.
+func (p *Parser) SetDelimiterEscapes(enabled bool) {
+	p.delimiterEscapes = enabled
+}
+
+// SetLocale selects the locale that locale-aware filters, such as currency, date, and t, use in templates
+// parsed by this parser. Template.WithLocale overrides it for a single template. The default is DefaultLocale.
+func (p *Parser) SetLocale(name string) {
+	p.locale = name
+}
 
-// NewParser creates a Parser with a compiled regular expression for efficiency.
-func NewParser() *Parser {
-	return &Parser{}
+// SetValidateFilters enables checking at parse time that every filter used by a template is registered.
+// It is off by default so filters may be registered after their templates are parsed.
+func (p *Parser) SetValidateFilters(validate bool) {
+	p.validateFilters = validate
 }
 
 // Parse transforms a template string into a Template.
 func (p *Parser) Parse(src string) (*Template, error) {
 	template := NewTemplate()
-	tokens := p.tokenize(src)
-	for _, token := range tokens {
-		if p.isVariable(token) {
-			p.addVariableNode(token, template)
+	template.missingBehavior = p.missingBehavior
+	template.unrenderableBehavior = p.unrenderableBehavior
+	template.unrenderablePlaceholder = p.unrenderablePlaceholder
+	template.onMissing = p.onMissing
+	template.complexRender = p.complexRender
+	template.textTransform = p.textTransform
+	template.disableMethodCalls = p.disableMethodCalls
+	template.delimiterEscapes = p.delimiterEscapes
+	template.locale = p.locale
+	line, column, offset := 1, 1, 0
+	for _, token := range p.tokenize(src) {
+		line, column = advancePosition(line, column, src[offset:token.offset])
+		offset = token.offset
+
+		count := len(template.Nodes)
+		if p.isVariable(token.text) {
+			p.addVariableNode(token.text, template)
 		} else {
-			p.addTextNode(token, template)
+			p.addTextNode(token.text, template)
+		}
+		// Escapes make node text differ from the source, so positions are recorded while parsing.
+		if p.delimiterEscapes && len(template.Nodes) > count {
+			template.positions = append(template.positions, position{line, column})
+		}
+	}
+	if p.textTransform != nil {
+		for _, node := range template.Nodes {
+			if node.Type == NodeText {
+				node.transformed = &transformedText{source: node.Text, output: p.textTransform(node.Text)}
+			}
+		}
+	}
+	if p.validateFilters {
+		if err := validateFilterNames(template); err != nil {
+			return nil, err
 		}
 	}
 	return template, nil
 }
 
+// validateFilterNames reports every filter used by the template that is not registered, with its position.
+func validateFilterNames(tpl *Template) error {
+	var errs []error
+	positions := tpl.nodePositions()
+	for i, node := range tpl.Nodes {
+		for _, name := range unknownFilters(node) {
+			errs = append(errs, fmt.Errorf("%w: filter '%s' at line %d, column %d", ErrFilterNotFound, name, positions[i].line, positions[i].column))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// unknownFilters lists the filters used by a node and its children that are not registered.
+func unknownFilters(node *Node) []string {
+	var unknown []string
+	for _, filter := range node.Filters {
+		if !filterRegistered(filter.Name) {
+			unknown = append(unknown, filter.Name)
+		}
+	}
+	for _, child := range node.Children {
+		unknown = append(unknown, unknownFilters(child)...)
+	}
+	return unknown
+}
+
+// token is a piece of template source along with the offset at which it starts.
+type token struct {
+	text   string
+	offset int
+}
+
 // tokenize divides the source string into tokens for easier parsing.
-func (p *Parser) tokenize(src string) []string {
-	tokens := make([]string, 0)
+func (p *Parser) tokenize(src string) []token {
+	tokens := make([]token, 0)
 
 	matches := variableRegex.FindAllStringIndex(src, -1)
 	start := 0
 	for _, match := range matches {
+		text := src[start:match[0]]
+		if p.delimiterEscapes {
+			// An odd number of backslashes before the delimiter escapes the variable, so it stays in the
+			// text; an even number stands for half as many literal backslashes.
+			backslashes := len(text) - len(strings.TrimRight(text, `\`))
+			if backslashes%2 == 1 {
+				continue
+			}
+			text = text[:len(text)-backslashes/2]
+		}
 		// Add text between variables as tokens
-		if start < match[0] {
-			tokens = append(tokens, src[start:match[0]])
+		if text != "" {
+			tokens = append(tokens, token{text: text, offset: start})
 		}
 		// Add variable token
-		tokens = append(tokens, src[match[0]:match[1]])
+		tokens = append(tokens, token{text: src[match[0]:match[1]], offset: match[0]})
 		start = match[1]
 	}
 	// Add remaining text as a token
 	if start < len(src) {
-		tokens = append(tokens, src[start:])
+		tokens = append(tokens, token{text: src[start:], offset: start})
 	}
 	return tokens
 }
@@ -58,34 +346,84 @@ func (p *Parser) isVariable(token string) bool {
 	return strings.HasPrefix(token, "{{") && strings.HasSuffix(token, "}}")
 }
 
-// Updated addVariableNode processes a variable token, parses out any filters, and adds it to the template.
+// addVariableNode processes a variable token, parses out any operators and filters, and adds it to the template.
 func (p *Parser) addVariableNode(token string, tpl *Template) {
 	// Extract the inner content of the variable token.
 	innerContent := strings.TrimSpace(token[2 : len(token)-2])
-	// Split the variable name from any filters.
-	parts := strings.SplitN(innerContent, "|", 2)
+
+	node, ok := parseExpression(innerContent)
+	if !ok {
+		// Keep malformed expressions as plain text.
+		p.addTextNode(token, tpl)
+		return
+	}
+	node.Text = token
+
+	// Add the new node to the template.
+	tpl.Nodes = append(tpl.Nodes, node)
+}
+
+// parseExpression parses the content of a variable token. Operands separated by ?? form a coalesce node.
+func parseExpression(expr string) (*Node, bool) {
+	operands := splitOutsideQuotes(expr, "??")
+	if len(operands) == 1 {
+		return parseConcatenation(expr)
+	}
+
+	node := &Node{Type: NodeCoalesce}
+	for _, operand := range operands {
+		child, ok := parseConcatenation(strings.TrimSpace(operand))
+		if !ok {
+			return nil, false
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, true
+}
+
+// parseConcatenation parses operands separated by ~, which binds tighter than ??, into a concat node.
+func parseConcatenation(expr string) (*Node, bool) {
+	operands := splitOutsideQuotes(expr, "~")
+	if len(operands) == 1 {
+		return parseOperand(expr)
+	}
+
+	node := &Node{Type: NodeConcat, Text: expr}
+	for _, operand := range operands {
+		child, ok := parseOperand(strings.TrimSpace(operand))
+		if !ok {
+			return nil, false
+		}
+		node.Children = append(node.Children, child)
+	}
+	return node, true
+}
+
+// parseOperand splits a variable name from any filters and returns the resulting variable node.
+func parseOperand(expr string) (*Node, bool) {
+	parts := splitOutsideQuotes(expr, "|")
 
 	varName := strings.TrimSpace(parts[0])
+	if !operandRegex.MatchString(varName) {
+		return nil, false
+	}
 
 	// Initialize filters slice.
 	var filters []Filter
 
 	// Check if there are filters to parse and use parseFilters if so.
 	if len(parts) > 1 {
-		filters = parseFilters(parts[1])
+		filters = parseFilters(strings.Join(parts[1:], "|"))
 	}
 
-	// Create a new variable node with the parsed variable name and filters.
-	node := &Node{
-		Type:     "variable",
+	return &Node{
+		Type:     NodeVariable,
 		Variable: varName,
 		Filters:  filters,
-		Text:     token,
-	}
-
-	// Add the new node to the template.
-	tpl.Nodes = append(tpl.Nodes, node)
+		Text:     expr,
+	}, true
 }
+
 func parseFilters(filterStr string) []Filter {
 	filters := make([]Filter, 0)
 
@@ -93,8 +431,8 @@ func parseFilters(filterStr string) []Filter {
 		return filters
 	}
 
-	// Splitting the entire filter string into individual filters
-	filterParts := strings.Split(filterStr, "|")
+	// Splitting the entire filter string into individual filters, keeping quoted pipes intact
+	filterParts := splitOutsideQuotes(filterStr, "|")
 	for _, part := range filterParts {
 		partTrimmed := strings.TrimSpace(part)
 		if partTrimmed == "" {
@@ -116,6 +454,33 @@ func parseFilters(filterStr string) []Filter {
 	return filters
 }
 
+// splitOutsideQuotes splits a string on the separator, ignoring separators inside quoted sections.
+func splitOutsideQuotes(s string, sep string) []string {
+	var parts []string
+	var inQuotes bool
+	var quoteChar byte
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		char := s[i]
+		switch {
+		case char == '"' || char == '\'':
+			if !inQuotes {
+				inQuotes = true
+				quoteChar = char
+			} else if char == quoteChar {
+				inQuotes = false
+			}
+		case !inQuotes && strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[start:i])
+			start = i + len(sep)
+			i = start - 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
 func splitArgsConsideringQuotes(argsStr string) []FilterArg {
 	var args []FilterArg
 	var currentArg strings.Builder
@@ -133,6 +498,8 @@ func splitArgsConsideringQuotes(argsStr string) []FilterArg {
 				args = append(args, StringArg{val: trimmedArg[1 : len(trimmedArg)-1]})
 			} else if number, err := strconv.ParseFloat(trimmedArg, 64); err == nil {
 				args = append(args, NumberArg{val: number})
+			} else if trimmedArg == "true" || trimmedArg == "false" {
+				args = append(args, BoolArg{val: trimmedArg == "true"})
 			} else {
 				// Treat as variable
 				args = append(args, VariableArg{name: trimmedArg})
@@ -168,9 +535,30 @@ func splitArgsConsideringQuotes(argsStr string) []FilterArg {
 	return args
 }
 
-// addTextNode adds a text token to the template
+// delimiterRegex matches a delimiter together with the backslashes before it.
+var delimiterRegex = regexp.MustCompile(`\\*(?:\{\{|\{%)`)
+
+// unescapeDelimiters halves the backslashes before each delimiter in text. The delimiter is always kept
+// as text; an odd backslash is the one that escaped it.
+func unescapeDelimiters(text string) string {
+	return delimiterRegex.ReplaceAllStringFunc(text, func(match string) string {
+		backslashes := strings.IndexByte(match, '{')
+		return strings.Repeat(`\`, backslashes/2) + match[backslashes:]
+	})
+}
+
+// addTextNode adds a text token to the template, unescaping delimiters when escapes are enabled.
 func (p *Parser) addTextNode(text string, tpl *Template) {
-	if text != "" {
-		tpl.Nodes = append(tpl.Nodes, &Node{Type: "text", Text: text})
+	if p.delimiterEscapes {
+		text = unescapeDelimiters(text)
+	}
+	if text == "" {
+		return
+	}
+	// Merge adjacent text, such as text around a malformed tag, so an all-text template is a single node.
+	if last := len(tpl.Nodes) - 1; last >= 0 && tpl.Nodes[last].Type == NodeText {
+		tpl.Nodes[last].Text += text
+		return
 	}
+	tpl.Nodes = append(tpl.Nodes, &Node{Type: NodeText, Text: text})
 }
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -1497,3 +1498,199 @@ func TestParserWithMultipleFiltersAndNumericArguments(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFilterWithBooleanArguments(t *testing.T) {
+	source := `{{ text|indent:2,false }}`
+	parser := NewParser()
+	tpl, err := parser.Parse(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &Template{
+		Nodes: []*Node{
+			{
+				Type:     "variable",
+				Variable: "text",
+				Filters: []Filter{
+					{Name: "indent", Args: []FilterArg{NumberArg{val: 2}, BoolArg{val: false}}},
+				},
+				Text: source,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(tpl, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, tpl)
+	}
+}
+
+func TestParseCoalesceExpression(t *testing.T) {
+	source := `{{ nickname ?? name|upper ?? "guest" }}`
+	parser := NewParser()
+	tpl, err := parser.Parse(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &Template{
+		Nodes: []*Node{
+			{
+				Type: "coalesce",
+				Text: source,
+				Children: []*Node{
+					{Type: "variable", Variable: "nickname", Text: "nickname"},
+					{Type: "variable", Variable: "name", Filters: []Filter{{Name: "upper"}}, Text: "name|upper"},
+					{Type: "variable", Variable: `"guest"`, Text: `"guest"`},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(tpl, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, tpl)
+	}
+}
+
+func TestParseConcatExpression(t *testing.T) {
+	source := `{{ first ~ " " ~ last|upper ?? "anonymous" }}`
+	parser := NewParser()
+	tpl, err := parser.Parse(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := &Template{
+		Nodes: []*Node{
+			{
+				Type: "coalesce",
+				Text: source,
+				Children: []*Node{
+					{
+						Type: "concat",
+						Text: `first ~ " " ~ last|upper`,
+						Children: []*Node{
+							{Type: "variable", Variable: "first", Text: "first"},
+							{Type: "variable", Variable: `" "`, Text: `" "`},
+							{Type: "variable", Variable: "last", Filters: []Filter{{Name: "upper"}}, Text: "last|upper"},
+						},
+					},
+					{Type: "variable", Variable: `"anonymous"`, Text: `"anonymous"`},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(tpl, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, tpl)
+	}
+}
+
+func TestParseWithFilterValidation(t *testing.T) {
+	parser := NewParser()
+	parser.SetValidateFilters(true)
+
+	tpl, err := parser.Parse("Hello, {{ name|capitalize|upper }}!")
+	if err != nil {
+		t.Fatalf("Unexpected error for registered filters: %v", err)
+	}
+	if len(tpl.Nodes) != 3 {
+		t.Errorf("Expected 3 nodes, got %d", len(tpl.Nodes))
+	}
+
+	_, err = parser.Parse("Hello,\n  {{ name|capitlize }} {{ nick ?? name|lowr }}")
+	if !errors.Is(err, ErrFilterNotFound) {
+		t.Fatalf("Expected ErrFilterNotFound, got %v", err)
+	}
+	expected := "filter not found: filter 'capitlize' at line 2, column 3\n" +
+		"filter not found: filter 'lowr' at line 2, column 24"
+	if err.Error() != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, err.Error())
+	}
+
+	// Unknown filters are only reported when validation is enabled.
+	if _, err := NewParser().Parse("{{ name|capitlize }}"); err != nil {
+		t.Errorf("Expected no error without validation, got %v", err)
+	}
+}
+
+func TestParseBraceHeavySnippets(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("x", 42)
+	ctx.Set("name", "app")
+
+	cases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"FunctionBody", "function() { return {{ x }}; }", "function() { return 42; }"},
+		{"ObjectLiteral", "const cfg = { name: '{{ name }}', size: {{ x }} };", "const cfg = { name: 'app', size: 42 };"},
+		{"TripleOpeningBrace", "{{{ x }}}", "{42}"},
+		{"StrayClosingBraces", "}} {{ x }} }}", "}} 42 }}"},
+		{"StrayOpeningBraces", "{{ {{ x }} {{", "{{ 42 {{"},
+		{"TemplateLiteral", "`${ {{ x }} }`", "`${ 42 }`"},
+		{"NestedBlocks", "if (a) { if (b) { f({{ x }}) } }", "if (a) { if (b) { f(42) } }"},
+		{"SingleBracesOnly", "map[string]struct{}{}", "map[string]struct{}{}"},
+		{"BraceInsideQuotedArgument", `{{ name|append:"}" }}`, "app}"},
+		{"BracesInsideQuotedLiteral", `{{ "{a}" }}`, "{a}"},
+		{"BraceInsideQuotedArgumentInText", `a { {{ name|append:"}}" }} } b`, "a { app}} } b"},
+		{"BracesInsideQuotedLiteralInText", `f({{ "{a}" }}, {{ x }})`, "f({a}, 42)"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.source, ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBackslashBeforeVariableWithoutEscapes(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("dir", "Users")
+
+	result, err := Render(`C:\{{ dir }} and path\\{{ dir }}`, ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != `C:\Users and path\\Users` {
+		t.Errorf("Expected backslashes to be plain text, got '%s'", result)
+	}
+}
+
+func TestParseEscapedDelimiters(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("name", "Jane")
+
+	cases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"EscapedVariable", `Use \{{ name }} to print a name.`, "Use {{ name }} to print a name."},
+		{"EscapedAndRealVariable", `\{{ name }} renders as {{ name }}`, "{{ name }} renders as Jane"},
+		{"EscapedTagDelimiter", `\{% if admin %}{{ name }}\{% endif %}`, "{% if admin %}Jane{% endif %}"},
+		{"EscapedMalformedVariable", `\{{ not valid | }} and {{ name|upper }}`, "{{ not valid | }} and JANE"},
+		{"BackslashOnlyEscapesDelimiter", `C:\path \{{ name }}`, `C:\path {{ name }}`},
+		{"DoubleBackslashBeforeVariable", `C:\\{{ name }}`, `C:\Jane`},
+		{"DoubleBackslashBeforeEscapedVariable", `\\\{{ name }} is {{ name }}`, `\{{ name }} is Jane`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.source, ctx, WithDelimiterEscapes())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
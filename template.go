@@ -1,12 +1,31 @@
 package template
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// Node types stored in Node.Type.
+const (
+	// NodeText is literal text, held in Text.
+	NodeText = "text"
+	// NodeVariable is a single operand, held in Variable, with optional Filters. Text holds the original expression.
+	NodeVariable = "variable"
+	// NodeCoalesce is a ?? expression whose operands are its Children.
+	NodeCoalesce = "coalesce"
+	// NodeConcat is a ~ expression whose operands are its Children.
+	NodeConcat = "concat"
+)
+
 // Node defines a single element within a template, such as text, variable, or control structure.
 type Node struct {
 	Type     string
@@ -14,11 +33,76 @@ type Node struct {
 	Variable string
 	Filters  []Filter
 	Children []*Node
+
+	// transformed caches the parser's text transform of Text. It is ignored once Text no longer matches it.
+	transformed *transformedText
+}
+
+// transformedText is the output of a text transform along with the text it was computed from.
+type transformedText struct {
+	source, output string
+}
+
+// Walk visits the template's nodes depth first, calling fn on each node before its children.
+// When fn returns false, the children of that node are skipped.
+func (t *Template) Walk(fn func(*Node) bool) {
+	walkNodes(t.Nodes, fn)
+}
+
+// walkNodes calls fn on each node and, unless fn returns false, walks its children.
+func walkNodes(nodes []*Node, fn func(*Node) bool) {
+	for _, node := range nodes {
+		if fn(node) {
+			walkNodes(node.Children, fn)
+		}
+	}
+}
+
+// maxPooledBufferSize caps the capacity of buffers returned to the pool, so one very large render
+// does not keep its memory alive.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool reuses output buffers across executions.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer takes an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns a buffer to the pool unless it has grown too large.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
 }
 
 // Template represents a structured template that can be executed with a given context.
 type Template struct {
 	Nodes []*Node
+
+	missingBehavior         MissingBehavior
+	unrenderableBehavior    UnrenderableBehavior
+	unrenderablePlaceholder string
+	onMissing               MissingHandler
+	complexRender           ComplexRender
+	textTransform           TextTransform
+	disableMethodCalls      bool
+	delimiterEscapes        bool
+	locale                  string
+
+	// positions holds the source position of each node when parsing changed node text, as escapes do.
+	positions []position
+
+	// messageDepth counts how many translated messages enclose this template while it renders one.
+	messageDepth int
 }
 
 // NewTemplate creates an empty template, ready to be populated with nodes.
@@ -26,13 +110,87 @@ func NewTemplate() *Template {
 	return &Template{Nodes: []*Node{}}
 }
 
+// Clone returns a deep copy of the template, so its nodes can be changed without affecting the original.
+func (t *Template) Clone() *Template {
+	clone := *t
+	clone.Nodes = cloneNodes(t.Nodes)
+	return &clone
+}
+
+// String reconstructs the template source from its nodes. Parsing the result with the same options
+// yields an equivalent template.
+func (t *Template) String() string {
+	var builder strings.Builder
+	for i, node := range t.Nodes {
+		if node.Type == NodeText && t.delimiterEscapes {
+			beforeTag := i+1 < len(t.Nodes) && t.Nodes[i+1].Type != NodeText
+			builder.WriteString(escapeDelimiters(node.Text, beforeTag))
+			continue
+		}
+		builder.WriteString(node.Text)
+	}
+	return builder.String()
+}
+
+// escapeDelimiters reverses unescapeDelimiters, so delimiters in text are not parsed as tags. When the
+// text is followed by a tag, its trailing backslashes are doubled so they do not escape the tag.
+func escapeDelimiters(text string, beforeTag bool) string {
+	text = delimiterRegex.ReplaceAllStringFunc(text, func(match string) string {
+		backslashes := strings.IndexByte(match, '{')
+		return strings.Repeat(`\`, 2*backslashes+1) + match[backslashes:]
+	})
+	if beforeTag {
+		text += strings.Repeat(`\`, len(text)-len(strings.TrimRight(text, `\`)))
+	}
+	return text
+}
+
+// cloneNodes deep copies a slice of nodes along with their filters and children.
+func cloneNodes(nodes []*Node) []*Node {
+	if nodes == nil {
+		return nil
+	}
+	cloned := make([]*Node, len(nodes))
+	for i, node := range nodes {
+		copied := *node
+		if node.Filters != nil {
+			copied.Filters = make([]Filter, len(node.Filters))
+			for j, filter := range node.Filters {
+				copied.Filters[j] = Filter{Name: filter.Name, Args: append([]FilterArg(nil), filter.Args...)}
+			}
+		}
+		copied.Children = cloneNodes(node.Children)
+		cloned[i] = &copied
+	}
+	return cloned
+}
+
 // Execute combines template data with the provided context to produce a string.
 func (t *Template) Execute(ctx Context) (string, error) {
-	var builder strings.Builder
-	if err := executeNodes(t.Nodes, ctx, &builder); err != nil {
-		return builder.String(), err
+	// Static templates need neither the context nor a buffer.
+	if text, ok := t.staticText(); ok {
+		return text, nil
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	if err := t.executeNodes(t.Nodes, ctx, buf); err != nil {
+		return buf.String(), err
+	}
+	return buf.String(), nil
+}
+
+// staticText returns the output of a template that contains no variables.
+func (t *Template) staticText() (string, bool) {
+	switch {
+	case len(t.Nodes) == 0:
+		return "", true
+	case len(t.Nodes) == 1 && t.Nodes[0].Type == NodeText:
+		return t.renderText(t.Nodes[0]), true
+	default:
+		return "", false
 	}
-	return builder.String(), nil
 }
 
 // MustExecute combines template data with the provided context to produce a string, ignoring errors.
@@ -41,11 +199,129 @@ func (t *Template) MustExecute(ctx Context) string {
 	return result
 }
 
-// executeNodes recursively processes a slice of nodes, appending the result to the builder.
-func executeNodes(nodes []*Node, ctx Context, builder *strings.Builder) error {
+// ExecuteOrEmpty renders the template for best-effort output such as logs. It never returns an error
+// and never panics: when execution stops early, or a filter or method called by the template panics,
+// it returns the output rendered up to that point, which is empty if nothing was rendered.
+func (t *Template) ExecuteOrEmpty(ctx Context) (result string) {
+	if text, ok := t.staticText(); ok {
+		return text
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	defer func() {
+		if recover() != nil {
+			result = buf.String()
+		}
+	}()
+
+	_ = t.executeNodes(t.Nodes, ctx, buf)
+	return buf.String()
+}
+
+// Validate resolves every variable referenced by the template, including variable filter arguments,
+// without rendering output or running filters. Methods are never called, so validating has no side
+// effects, and a reference that can only be resolved through a method is reported. It returns one error
+// per unresolved reference, each reporting the line and column of the tag that contains it.
+func (t *Template) Validate(ctx Context) []error {
+	var errs []error
+	withoutMethods := *t
+	withoutMethods.disableMethodCalls = true
+	positions := t.nodePositions()
+	for i, node := range t.Nodes {
+		for _, name := range withoutMethods.unresolvedReferences(node, ctx) {
+			errs = append(errs, fmt.Errorf("%w: '%s' at line %d, column %d", ErrContextKeyNotFound, name, positions[i].line, positions[i].column))
+		}
+	}
+	return errs
+}
+
+// position is the line and column at which a node starts in the template source.
+type position struct {
+	line, column int
+}
+
+// nodePositions returns the source position of each node. Unless positions were recorded while
+// parsing, they are computed from the node text, which matches the source.
+func (t *Template) nodePositions() []position {
+	if len(t.positions) == len(t.Nodes) {
+		return t.positions
+	}
+	positions := make([]position, len(t.Nodes))
+	line, column := 1, 1
+	for i, node := range t.Nodes {
+		positions[i] = position{line, column}
+		line, column = advancePosition(line, column, node.Text)
+	}
+	return positions
+}
+
+// advancePosition returns the line and column reached after text, starting from the given position.
+func advancePosition(line, column int, text string) (int, int) {
+	for _, char := range text {
+		if char == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// unresolvedReferences lists the variables referenced by a node that cannot be resolved.
+// Operands of a coalesce expression may be missing by design and are not reported.
+func (t *Template) unresolvedReferences(node *Node, ctx Context) []string {
+	var missing []string
+	switch node.Type {
+	case NodeVariable:
+		if _, err := t.resolveVariable(node.Variable, ctx); err != nil && missingInputFilter(node.Filters) < 0 {
+			missing = append(missing, node.Variable)
+		}
+		for _, filter := range node.Filters {
+			for _, arg := range filter.Args {
+				if arg, ok := arg.(VariableArg); ok {
+					if _, err := ctx.get(arg.name, !t.disableMethodCalls); err != nil {
+						missing = append(missing, arg.name)
+					}
+				}
+			}
+		}
+	case NodeConcat:
+		for _, child := range node.Children {
+			missing = append(missing, t.unresolvedReferences(child, ctx)...)
+		}
+	}
+	return missing
+}
+
+// executeNodes recursively processes a slice of nodes, appending the result to the buffer.
+func (t *Template) executeNodes(nodes []*Node, ctx Context, buf *bytes.Buffer) error {
 	var firstErr error
-	for _, node := range nodes {
-		err := executeNode(node, ctx, builder)
+	var positions []position
+	for i, node := range nodes {
+		err := t.executeNode(node, ctx, buf)
+		if err != nil && isMissingValueError(err) {
+			var missing *missingReferenceError
+			if t.onMissing != nil && errors.As(err, &missing) && missing.template == t {
+				// Positions are only needed once something is missing.
+				if positions == nil {
+					positions = t.nodePositions()
+				}
+				t.onMissing(missing.name, positions[i].line, positions[i].column)
+			}
+			switch t.missingBehavior {
+			case MissingError:
+				return err
+			case MissingEmpty:
+				// Only a missing value to render is silenced; a missing filter argument is still reported.
+				if !errors.Is(err, ErrFilterArgNotFound) {
+					err = nil
+				}
+			case MissingKeepRaw:
+				// Keep the error to return it with the output.
+			}
+		}
 		if err != nil && firstErr == nil {
 			firstErr = err
 		}
@@ -54,13 +330,34 @@ func executeNodes(nodes []*Node, ctx Context, builder *strings.Builder) error {
 }
 
 // executeNode executes a single node, handling text and variable nodes differently.
-func executeNode(node *Node, ctx Context, builder *strings.Builder) error {
+func (t *Template) executeNode(node *Node, ctx Context, buf *bytes.Buffer) error {
 	switch node.Type {
-	case "text":
-		builder.WriteString(node.Text)
-	case "variable":
-		value, err := executeVariableNode(node, ctx)
-		builder.WriteString(value)
+	case NodeText:
+		buf.WriteString(t.renderText(node))
+	case NodeVariable, NodeCoalesce, NodeConcat:
+		value, err := t.executeVariableNode(node, ctx)
+		if err != nil && errors.Is(err, ErrUnrenderableValue) {
+			switch t.unrenderableBehavior {
+			case UnrenderablePlaceholder:
+				buf.WriteString(t.unrenderablePlaceholder)
+				return nil
+			case UnrenderableError:
+				return err
+			case UnrenderableKeepRaw:
+				buf.WriteString(value)
+				return nil
+			}
+		}
+		if err != nil && isMissingValueError(err) {
+			switch t.missingBehavior {
+			case MissingEmpty, MissingError:
+				// Render nothing; executeNodes decides whether to report the error.
+				return err
+			case MissingKeepRaw:
+				// Fall through to render the original tag.
+			}
+		}
+		buf.WriteString(value)
 		if err != nil {
 			return err
 		}
@@ -70,38 +367,149 @@ func executeNode(node *Node, ctx Context, builder *strings.Builder) error {
 	return nil
 }
 
+// renderText returns the output of a text node, applying the template's text transform if one is set.
+// The transform computed at parse time is reused unless the node was added or its text changed since.
+func (t *Template) renderText(node *Node) string {
+	if t.textTransform == nil {
+		return node.Text
+	}
+	if cached := node.transformed; cached != nil && cached.source == node.Text {
+		return cached.output
+	}
+	return t.textTransform(node.Text)
+}
+
 // executeVariableNode resolves and processes a variable node, applying any filters.
-func executeVariableNode(node *Node, ctx Context) (string, error) {
-	value, err := resolveVariable(node.Variable, ctx)
+func (t *Template) executeVariableNode(node *Node, ctx Context) (string, error) {
+	value, err := t.evaluateNode(node, ctx)
 	if err != nil {
 		// Instead of returning an error, return the original variable placeholder.
 		return node.Text, err
 	}
 
+	result, err := formatValue(value, t.complexRender)
+	if errors.Is(err, ErrComplexValue) {
+		return node.Text, err
+	}
+	if err != nil {
+		return node.Text, fmt.Errorf("%w: %w", ErrUnrenderableValue, err)
+	}
+
+	return result, nil
+}
+
+// evaluateNode computes the value of an expression node without converting it to a string.
+func (t *Template) evaluateNode(node *Node, ctx Context) (interface{}, error) {
+	switch node.Type {
+	case NodeCoalesce:
+		return t.evaluateCoalesce(node, ctx)
+	case NodeConcat:
+		return t.evaluateConcat(node, ctx)
+	}
+
+	value, err := t.resolveVariable(node.Variable, ctx)
+	fs := node.Filters
+	if err != nil {
+		// A missing value skips the filters before the first one that accepts a missing input.
+		i := missingInputFilter(fs)
+		if !isMissingValueError(err) {
+			return nil, err
+		}
+		if i < 0 {
+			return nil, &missingReferenceError{template: t, name: node.Variable, err: err}
+		}
+		value, fs = nil, fs[i:]
+	}
+
 	// Apply filters to the resolved value.
-	if len(node.Filters) > 0 {
-		value, err = ApplyFilters(value, node.Filters, ctx)
+	if len(fs) > 0 {
+		return t.applyFilters(value, fs, ctx)
+	}
+	return value, nil
+}
+
+// evaluateCoalesce returns the first operand that is neither missing nor empty, evaluating left to right.
+// When every operand is empty the result is an empty string.
+func (t *Template) evaluateCoalesce(node *Node, ctx Context) (interface{}, error) {
+	for _, child := range node.Children {
+		value, err := t.evaluateNode(child, ctx)
 		if err != nil {
-			return node.Text, err
+			if isMissingValueError(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !isEmptyValue(value) {
+			return value, nil
 		}
 	}
+	return "", nil
+}
 
-	result, err := convertToString(value)
-	if err != nil {
-		return node.Text, nil //nolint: nilerr // Return the original variable placeholder.
+// evaluateConcat stringifies every operand with the template's complex value mode and joins the results.
+func (t *Template) evaluateConcat(node *Node, ctx Context) (interface{}, error) {
+	var builder strings.Builder
+	for _, child := range node.Children {
+		value, err := t.evaluateNode(child, ctx)
+		if err != nil {
+			return nil, err
+		}
+		str, err := formatValue(value, t.complexRender)
+		if errors.Is(err, ErrComplexValue) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrUnrenderableValue, err)
+		}
+		builder.WriteString(str)
 	}
+	return builder.String(), nil
+}
 
-	return result, nil
+// missingReferenceError wraps the error for a variable that cannot be resolved, recording its name and the
+// template that resolved it, so the OnMissing handler is called without resolving the variable again.
+type missingReferenceError struct {
+	template *Template
+	name     string
+	err      error
+}
+
+func (e *missingReferenceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *missingReferenceError) Unwrap() error {
+	return e.err
+}
+
+// isMissingValueError reports whether err signals a value that could not be found in the context.
+func isMissingValueError(err error) bool {
+	return errors.Is(err, ErrContextKeyNotFound) ||
+		errors.Is(err, ErrContextInvalidKeyType) ||
+		errors.Is(err, ErrContextIndexOutOfRange)
 }
 
 // resolveVariable retrieves and formats a variable's value from the context, supporting nested keys.
-func resolveVariable(variable string, ctx Context) (interface{}, error) {
+func (t *Template) resolveVariable(variable string, ctx Context) (interface{}, error) {
 	// Directly return string literals.
-	if strings.HasPrefix(variable, "'") && strings.HasSuffix(variable, "'") {
-		return strings.Trim(variable, "'"), nil
+	if len(variable) >= 2 && (variable[0] == '\'' || variable[0] == '"') && variable[len(variable)-1] == variable[0] {
+		return variable[1 : len(variable)-1], nil
+	}
+
+	// Boolean and numeric literals are used only when the context has no key of that name.
+	if literal, ok := parseLiteral(variable); ok {
+		if value, err := ctx.get(variable, !t.disableMethodCalls); err == nil {
+			return value, nil
+		}
+		return literal, nil
 	}
 
-	value, err := ctx.Get(variable)
+	// Paths with bracket access bypass dot-splitting for the bracketed keys.
+	if strings.Contains(variable, "[") {
+		return t.resolveBracketPath(variable, ctx)
+	}
+
+	value, err := ctx.get(variable, !t.disableMethodCalls)
 	if err != nil {
 		return nil, err
 	}
@@ -109,11 +517,123 @@ func resolveVariable(variable string, ctx Context) (interface{}, error) {
 	return value, nil
 }
 
+// parseLiteral parses a boolean or numeric literal such as true, 42, or -3.5.
+func parseLiteral(variable string) (interface{}, bool) {
+	if variable == "true" || variable == "false" {
+		return variable == "true", true
+	}
+	if variable != "" && (variable[0] == '-' || (variable[0] >= '0' && variable[0] <= '9')) {
+		if i, err := strconv.Atoi(variable); err == nil {
+			return i, true
+		}
+		if f, err := strconv.ParseFloat(variable, 64); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// resolveBracketPath resolves a path such as data["a.b"] or data[key].field. A quoted key is used
+// as written, while any other key is resolved as an expression and converted to a string.
+func (t *Template) resolveBracketPath(variable string, ctx Context) (interface{}, error) {
+	keys, err := t.splitBracketPath(variable, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var current interface{} = ctx
+	for _, key := range keys {
+		value, ok, err := resolveSegment(current, key, !t.disableMethodCalls)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s': %w", ErrContextMethodCall, variable, err)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrContextKeyNotFound, variable)
+		}
+		current = value
+	}
+	return current, nil
+}
+
+// splitBracketPath breaks a path into its keys, resolving the contents of each bracket pair.
+func (t *Template) splitBracketPath(variable string, ctx Context) ([]string, error) {
+	var keys []string
+	rest := variable
+	for rest != "" {
+		start := strings.IndexByte(rest, '[')
+		if start == -1 {
+			start = len(rest)
+		}
+		for _, part := range strings.Split(rest[:start], ".") {
+			if part != "" {
+				keys = append(keys, part)
+			}
+		}
+		if start == len(rest) {
+			break
+		}
+
+		end := closingBracketIndex(rest, start)
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unclosed bracket in '%s'", ErrContextInvalidKeyType, variable)
+		}
+		key, err := t.resolveVariable(strings.TrimSpace(rest[start+1:end]), ctx)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, toString(key))
+		rest = rest[end+1:]
+	}
+	return keys, nil
+}
+
+// closingBracketIndex returns the index of the bracket closing the one at start, skipping quoted sections.
+func closingBracketIndex(s string, start int) int {
+	var quoteChar byte
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case quoteChar != 0:
+			if s[i] == quoteChar {
+				quoteChar = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quoteChar = s[i]
+		case s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
 // convertToString attempts to convert various types to a string, handling common and complex types distinctly.
 func convertToString(value interface{}) (string, error) {
+	return formatValue(value, ComplexRenderJSON)
+}
+
+// formatValue converts a value to a string like convertToString, rendering maps, slices, and structs
+// that have no text form of their own as selected by complexRender.
+func formatValue(value interface{}, complexRender ComplexRender) (string, error) {
 	switch v := value.(type) {
 	case string:
 		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		// Match encoding/json, which switches to exponent notation outside this range.
+		if abs := math.Abs(v); abs == 0 || (abs >= 1e-6 && abs < 1e21) {
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+		return formatJSON(v)
 	case []string:
 		return fmt.Sprintf("[%s]", strings.Join(v, ", ")), nil
 	case []int, []int64, []float64, []bool:
@@ -125,13 +645,81 @@ func convertToString(value interface{}) (string, error) {
 		// Customize the time format as needed
 		return v.Format("2006-01-02 15:04:05"), nil
 	case fmt.Stringer:
+		if isNilPointer(v) {
+			return formatJSON(v)
+		}
 		return v.String(), nil
-	default:
-		// Fallback for more complex or unknown types: use JSON serialization
-		jsonBytes, err := json.MarshalIndent(v, "", "  ")
+	case error:
+		if isNilPointer(v) {
+			return formatJSON(v)
+		}
+		return v.Error(), nil
+	case encoding.TextMarshaler:
+		// Types without a String method, such as UUIDs, often provide their text form this way.
+		if isNilPointer(v) {
+			return formatJSON(v)
+		}
+		text, err := v.MarshalText()
 		if err != nil {
 			return "", fmt.Errorf("could not convert value to string: %w", err)
 		}
-		return string(jsonBytes), nil
+		return string(text), nil
+	default:
+		// A value whose String method has a pointer receiver is rendered through a copy.
+		if str, ok := addressableString(v); ok {
+			return str, nil
+		}
+		return formatComplex(v, complexRender)
+	}
+}
+
+// formatComplex renders a value that has no text form of its own. Maps, slices, arrays, and structs
+// follow complexRender; everything else uses JSON serialization.
+func formatComplex(value interface{}, complexRender ComplexRender) (string, error) {
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		switch complexRender {
+		case ComplexRenderGoSyntax:
+			return fmt.Sprintf("%v", value), nil
+		case ComplexRenderError:
+			return "", fmt.Errorf("%w: %T", ErrComplexValue, value)
+		case ComplexRenderJSON:
+			// Use JSON serialization below.
+		}
+	default:
+		// Scalars of other types, and nil, use JSON serialization.
+	}
+	return formatJSON(value)
+}
+
+// stringerType is the reflect type of fmt.Stringer.
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// addressableString calls String on a copy of a value whose String method is declared on a pointer receiver.
+func addressableString(value interface{}) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	valueType := reflect.TypeOf(value)
+	if valueType.Kind() == reflect.Ptr || !reflect.PointerTo(valueType).Implements(stringerType) {
+		return "", false
+	}
+	ptr := reflect.New(valueType)
+	ptr.Elem().Set(reflect.ValueOf(value))
+	return ptr.Interface().(fmt.Stringer).String(), true
+}
+
+// isNilPointer reports whether value holds a nil pointer, whose methods may not be safe to call.
+func isNilPointer(value interface{}) bool {
+	ref := reflect.ValueOf(value)
+	return ref.Kind() == reflect.Ptr && ref.IsNil()
+}
+
+// formatJSON renders a value as indented JSON.
+func formatJSON(value interface{}) (string, error) {
+	jsonBytes, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not convert value to string: %w", err)
 	}
+	return string(jsonBytes), nil
 }
@@ -1,6 +1,15 @@
 package template
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -66,6 +75,35 @@ func TestTemplateExecution(t *testing.T) {
 	}
 }
 
+type testStatus int
+
+var testStatusActive testStatus = 1
+
+func (s testStatus) String() string {
+	if s == testStatusActive {
+		return "active"
+	}
+	return "inactive"
+}
+
+// testVersion has exported fields that JSON would encode, but its String method takes precedence.
+type testVersion struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+}
+
+func (v *testVersion) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// testUUID implements encoding.TextMarshaler but not fmt.Stringer, like many UUID types.
+type testUUID [16]byte
+
+func (u testUUID) MarshalText() ([]byte, error) {
+	h := hex.EncodeToString(u[:])
+	return []byte(h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]), nil
+}
+
 func TestConvertToString(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -97,6 +135,31 @@ func TestConvertToString(t *testing.T) {
 			input:    []bool{true, false, true},
 			expected: "[true, false, true]",
 		},
+		{
+			name:     "Int",
+			input:    42,
+			expected: "42",
+		},
+		{
+			name:     "Float64",
+			input:    1000000.50,
+			expected: "1000000.5",
+		},
+		{
+			name:     "WholeFloat64",
+			input:    600.0,
+			expected: "600",
+		},
+		{
+			name:     "Bool",
+			input:    true,
+			expected: "true",
+		},
+		{
+			name:     "Nil",
+			input:    nil,
+			expected: "null",
+		},
 		{
 			name:     "Time",
 			input:    time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
@@ -107,6 +170,46 @@ func TestConvertToString(t *testing.T) {
 			input:    map[string]interface{}{"name": "John Doe", "age": 30},
 			expected: "{\n  \"age\": 30,\n  \"name\": \"John Doe\"\n}",
 		},
+		{
+			name:     "Stringer",
+			input:    testStatusActive,
+			expected: "active",
+		},
+		{
+			name:     "PointerToStringer",
+			input:    &testStatusActive,
+			expected: "active",
+		},
+		{
+			name:     "PointerReceiverStringer",
+			input:    testVersion{Major: 1, Minor: 2},
+			expected: "v1.2",
+		},
+		{
+			name:     "PointerToPointerReceiverStringer",
+			input:    &testVersion{Major: 3, Minor: 4},
+			expected: "v3.4",
+		},
+		{
+			name:     "NilPointerStringer",
+			input:    (*testVersion)(nil),
+			expected: "null",
+		},
+		{
+			name:     "Error",
+			input:    errors.New("disk full"),
+			expected: "disk full",
+		},
+		{
+			name:     "TextMarshaler",
+			input:    testUUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00},
+			expected: "123e4567-e89b-12d3-a456-426614174000",
+		},
+		{
+			name:     "IP",
+			input:    net.ParseIP("192.168.0.1"),
+			expected: "192.168.0.1",
+		},
 		{
 			name:     "HandleErrorInJSONFallback",
 			input:    make(chan int),
@@ -127,3 +230,355 @@ func TestConvertToString(t *testing.T) {
 		})
 	}
 }
+
+// closeCounter counts calls to its Close method.
+type closeCounter struct {
+	calls int
+}
+
+func (c *closeCounter) Close() error {
+	c.calls++
+	return nil
+}
+
+// TestTemplateValidateCallsNoMethods verifies that Validate reports method paths instead of calling them.
+func TestTemplateValidateCallsNoMethods(t *testing.T) {
+	tmpl, err := Parse("{{ conn.Close }}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	conn := &closeCounter{}
+	errs := tmpl.Validate(Context{"conn": conn})
+	if conn.calls != 0 {
+		t.Errorf("Expected Validate not to call Close, got %d calls", conn.calls)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrContextKeyNotFound) {
+		t.Errorf("Expected the method path to be reported, got %v", errs)
+	}
+}
+
+// TestTemplateValidate verifies that Validate reports only the unresolved references, with their positions.
+func TestTemplateValidate(t *testing.T) {
+	source := "Hello, {{ userName }}!\n" +
+		"Email: {{ profile.contacts.email }}, Phone: {{ profile.contacts.phone }}\n" +
+		"{{ profile.bio|truncate:limit }} {{ nickname ?? userName }} {{ 'literal' }} {{ userName ~ suffix }}"
+
+	tmpl, err := Parse(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	errs := tmpl.Validate(mockUserProfileContext())
+	expected := []string{
+		"key not found in context: 'profile.contacts.phone' at line 2, column 45",
+		"key not found in context: 'limit' at line 3, column 1",
+		"key not found in context: 'suffix' at line 3, column 77",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, err := range errs {
+		if !errors.Is(err, ErrContextKeyNotFound) {
+			t.Errorf("Expected error %d to wrap ErrContextKeyNotFound, got %v", i, err)
+		}
+		if err.Error() != expected[i] {
+			t.Errorf("Expected '%s', got '%s'", expected[i], err.Error())
+		}
+	}
+}
+
+// TestTemplateClone verifies that changing a clone's nodes leaves the original template untouched.
+func TestTemplateClone(t *testing.T) {
+	tmpl, err := Parse("Hi {{ userName|upper }}, {{ nickname ?? userName }}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := mockUserProfileContext()
+
+	clone := tmpl.Clone()
+	if !reflect.DeepEqual(clone, tmpl) {
+		t.Fatalf("Expected clone to equal the original")
+	}
+
+	clone.Nodes = append([]*Node{{Type: NodeText, Text: "> "}}, clone.Nodes...)
+	clone.Nodes[2].Filters[0].Name = "lower"
+	clone.Nodes[4].Children[1].Variable = "profile.age"
+
+	cloneResult, err := clone.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cloneResult != "> Hi janedoe, 29" {
+		t.Errorf("Expected clone output '> Hi janedoe, 29', got '%s'", cloneResult)
+	}
+
+	result, err := tmpl.Execute(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != "Hi JANEDOE, JaneDoe" {
+		t.Errorf("Expected original output 'Hi JANEDOE, JaneDoe', got '%s'", result)
+	}
+}
+
+func TestTemplateWalk(t *testing.T) {
+	tmpl, err := Parse("Hi {{ name|upper }}, {{ nickname ?? first ~ last }}!")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var visited []string
+	tmpl.Walk(func(node *Node) bool {
+		switch node.Type {
+		case NodeText:
+			visited = append(visited, NodeText+":"+node.Text)
+		case NodeVariable:
+			visited = append(visited, NodeVariable+":"+node.Variable)
+		default:
+			visited = append(visited, node.Type)
+		}
+		return true
+	})
+	expected := []string{
+		"text:Hi ", "variable:name", "text:, ",
+		"coalesce", "variable:nickname", "concat", "variable:first", "variable:last",
+		"text:!",
+	}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected %v, got %v", expected, visited)
+	}
+
+	var topLevel int
+	tmpl.Walk(func(node *Node) bool {
+		topLevel++
+		return node.Type != NodeCoalesce
+	})
+	if topLevel != 5 {
+		t.Errorf("Expected 5 nodes when skipping coalesce operands, got %d", topLevel)
+	}
+}
+
+func TestTemplateString(t *testing.T) {
+	sources := []string{
+		"",
+		"Plain text only.",
+		"Hello, {{ name }}!",
+		"{{ title|upper|truncate:10 }} by {{ author ?? 'anonymous' }}",
+		"{{ first ~ ' ' ~ last }}\n{{ items[0]|default:\"none\" }}",
+		"Write \\{{ name }} for a variable and \\{% tag %} for a tag.",
+		"{{ 'a }} b' }} and {{ missing }}",
+		`C:\\{{ dir }} and \\\{{ literal }} and \\\{% tag`,
+	}
+
+	for _, source := range sources {
+		for _, escapes := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/escapes=%t", source, escapes), func(t *testing.T) {
+				opts := []Option{WithDelimiterEscapes()}
+				if !escapes {
+					opts = nil
+				}
+				tmpl, err := Parse(source, opts...)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if tmpl.String() != source {
+					t.Errorf("Expected '%s', got '%s'", source, tmpl.String())
+				}
+
+				reparsed, err := Parse(tmpl.String(), opts...)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if !reflect.DeepEqual(reparsed, tmpl) {
+					t.Errorf("Expected reparsed template to equal the original for '%s'", source)
+				}
+			})
+		}
+	}
+}
+
+// TestConcurrentExecute runs a shared template from many goroutines; run it with -race.
+func TestConcurrentExecute(t *testing.T) {
+	tmpl, err := Parse("Hello, {{ userName|upper }}! You are {{ profile.age }}.")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := mockUserProfileContext()
+
+	var wg sync.WaitGroup
+	results := make(chan string, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				result, err := tmpl.Execute(ctx)
+				if err != nil {
+					results <- err.Error()
+					return
+				}
+				if result != "Hello, JANEDOE! You are 29." {
+					results <- result
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	for result := range results {
+		t.Errorf("Unexpected result: %s", result)
+	}
+}
+
+func BenchmarkExecute(b *testing.B) {
+	tmpl, err := Parse("Hello, {{ userName }}! Bio: {{ profile.bio }} Email: {{ profile.contacts.email }}.")
+	if err != nil {
+		b.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := mockUserProfileContext()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tmpl.Execute(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestStaticTemplateExecution verifies that a text-only template renders its source without using the context.
+func TestStaticTemplateExecution(t *testing.T) {
+	source := "<footer>\n  Copyright Example Inc. {{ not a tag }\n</footer>"
+	tmpl, err := Parse(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	result, err := tmpl.Execute(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result != source {
+		t.Errorf("Expected '%s', got '%s'", source, result)
+	}
+
+	malformed, err := Parse("a {{ x | f:y ?? }} b {{ x | f:y ~ }} c")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(malformed.Nodes) != 1 {
+		t.Errorf("Expected text around malformed tags to form one node, got %d nodes", len(malformed.Nodes))
+	}
+
+	empty, err := Parse("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result, err := empty.Execute(nil); err != nil || result != "" {
+		t.Errorf("Expected empty output without error, got '%s', %v", result, err)
+	}
+}
+
+func BenchmarkExecuteStatic(b *testing.B) {
+	tmpl, err := Parse("<footer>Copyright Example Inc. All rights reserved.</footer>")
+	if err != nil {
+		b.Fatalf("Unexpected error: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tmpl.Execute(nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestConvertToStringMatchesJSON verifies that the scalar fast paths render exactly what the JSON fallback would.
+func TestConvertToStringMatchesJSON(t *testing.T) {
+	values := []interface{}{
+		0, -17, int64(1) << 62, int32(-5), uint(7), uint64(1) << 63,
+		0.0, math.Copysign(0, -1), 0.1, -2.5, 1e-6, 1e-7, 123456789.125, 1e20, 1e21, -1e21, math.MaxFloat64,
+		true, false,
+	}
+
+	for _, value := range values {
+		expected, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		result, err := convertToString(value)
+		if err != nil {
+			t.Fatalf("Unexpected error for %#v: %v", value, err)
+		}
+		if result != string(expected) {
+			t.Errorf("Expected '%s' for %#v, got '%s'", expected, value, result)
+		}
+	}
+}
+
+// TestMapOutputIsSorted verifies that maps render with sorted keys at every level, whatever their type
+// or the order their keys were inserted in.
+func TestMapOutputIsSorted(t *testing.T) {
+	type settings struct {
+		Name   string
+		Labels map[string]string
+	}
+	keys := []string{"delta", "alpha", "echo", "charlie", "bravo"}
+
+	build := func(order []string) interface{} {
+		labels := map[string]string{}
+		nested := map[string]interface{}{}
+		codes := map[int]string{}
+		for _, key := range order {
+			labels[key] = key
+			nested[key] = map[string]interface{}{"z" + key: nil, key: len(key)}
+			codes[int(key[0])] = key
+		}
+		return map[string]interface{}{
+			"settings": settings{Name: "site", Labels: labels},
+			"nested":   nested,
+			"codes":    codes,
+		}
+	}
+
+	forward := build(keys)
+	reversed := make([]string, len(keys))
+	for i, key := range keys {
+		reversed[len(keys)-1-i] = key
+	}
+
+	for i := 0; i < 20; i++ {
+		first, err := convertToString(forward)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		second, err := convertToString(build(reversed))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if first != second {
+			t.Fatalf("Expected identical output, got:\n%s\nand:\n%s", first, second)
+		}
+		if !strings.Contains(first, "\"alpha\": \"alpha\",\n      \"bravo\": \"bravo\",\n      \"charlie\"") {
+			t.Errorf("Expected sorted nested keys, got:\n%s", first)
+		}
+	}
+}
+
+func BenchmarkConvertToString(b *testing.B) {
+	values := []interface{}{"text", 42, int64(42), 3.14, true, []string{"a", "b"}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, value := range values {
+			if _, err := convertToString(value); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Helper function to ensure the value is a string
@@ -44,3 +46,286 @@ func dereferenceIfNeeded(input interface{}) interface{} {
 	}
 	return input
 }
+
+// toFloat64 attempts to convert an interface{} to a float64.
+func toFloat64(input interface{}) (float64, error) {
+	input = dereferenceIfNeeded(input)
+	switch v := input.(type) {
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return f, nil
+		}
+		return 0, fmt.Errorf("%w: unable to parse '%v' as number", ErrFilterInputNotNumeric, input)
+	default:
+		return 0, fmt.Errorf("%w: received %T", ErrFilterInputNotNumeric, input)
+	}
+}
+
+// toNumber normalizes numeric input to an int for integral kinds and integer strings, or a float64 otherwise.
+func toNumber(input interface{}) (interface{}, error) {
+	input = dereferenceIfNeeded(input)
+	valRef := reflect.ValueOf(input)
+	switch valRef.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(valRef.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(valRef.Uint()), nil //nolint: gosec // Template numbers are expected to fit in an int.
+	case reflect.String:
+		if i, err := strconv.Atoi(strings.TrimSpace(valRef.String())); err == nil {
+			return i, nil
+		}
+	}
+	return toFloat64(input)
+}
+
+// toSlice converts any slice or array into a slice of interface{} values.
+func toSlice(input interface{}) ([]interface{}, error) {
+	valRef := reflect.ValueOf(dereferenceIfNeeded(input))
+	if valRef.Kind() != reflect.Slice && valRef.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%w: received %T", ErrFilterInputNotSlice, input)
+	}
+
+	result := make([]interface{}, 0, valRef.Len())
+	for i := 0; i < valRef.Len(); i++ {
+		result = append(result, valRef.Index(i).Interface())
+	}
+	return result, nil
+}
+
+// resolveField looks up a dot-separated path on maps, slices, arrays, and structs.
+// Struct fields are matched by name or by their json tag.
+func resolveField(input interface{}, path string) (interface{}, bool) {
+	current := input
+	for _, part := range strings.Split(path, ".") {
+		value, ok := resolveKey(current, part)
+		if !ok {
+			return nil, false
+		}
+		current = value
+	}
+	return current, true
+}
+
+// resolveKey looks up a single map key, slice index, or struct field, or asks a Resolver for the key.
+func resolveKey(input interface{}, key string) (interface{}, bool) {
+	if resolver, ok := input.(Resolver); ok {
+		return resolver.Resolve(key)
+	}
+	valRef := reflect.ValueOf(input)
+	for valRef.Kind() == reflect.Ptr || valRef.Kind() == reflect.Interface {
+		if valRef.IsNil() {
+			return nil, false
+		}
+		valRef = valRef.Elem()
+	}
+
+	switch valRef.Kind() {
+	case reflect.Map:
+		if valRef.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		value := valRef.MapIndex(reflect.ValueOf(key).Convert(valRef.Type().Key()))
+		if !value.IsValid() {
+			return nil, false
+		}
+		return value.Interface(), true
+	case reflect.Slice, reflect.Array:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= valRef.Len() {
+			return nil, false
+		}
+		return valRef.Index(index).Interface(), true
+	case reflect.Struct:
+		field, ok := structField(valRef, key)
+		if !ok {
+			return nil, false
+		}
+		return field.Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// resolveSegment resolves one path segment with resolveKey, falling back, when callMethods is set, to a
+// zero-argument method of that name. A method may return a value, an error, or a value and an error;
+// the error is passed on. Methods of Context itself are never called.
+func resolveSegment(input interface{}, key string, callMethods bool) (interface{}, bool, error) {
+	_, isContext := input.(Context)
+	if value, ok := resolveKey(input, key); ok {
+		return value, true, nil
+	}
+	if isContext || !callMethods {
+		return nil, false, nil
+	}
+	return callMethod(input, key)
+}
+
+// errorType is the reflect type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// callMethod calls the exported method name on input when it takes no arguments and returns a value,
+// an error, or a value and an error. A method that returns only an error yields nil when it succeeds.
+// Methods with pointer receivers are called on a copy of a non-pointer input.
+func callMethod(input interface{}, name string) (interface{}, bool, error) {
+	if input == nil {
+		return nil, false, nil
+	}
+	valRef := reflect.ValueOf(input)
+	if valRef.Kind() == reflect.Ptr && valRef.IsNil() {
+		return nil, false, nil
+	}
+	method := valRef.MethodByName(name)
+	if !method.IsValid() && valRef.Kind() != reflect.Ptr {
+		ptr := reflect.New(valRef.Type())
+		ptr.Elem().Set(valRef)
+		method = ptr.MethodByName(name)
+	}
+	if !method.IsValid() {
+		return nil, false, nil
+	}
+
+	methodType := method.Type()
+	if methodType.NumIn() != 0 {
+		return nil, false, nil
+	}
+	switch {
+	case methodType.NumOut() == 1 && methodType.Out(0) == errorType:
+		if err, _ := method.Call(nil)[0].Interface().(error); err != nil {
+			return nil, false, err
+		}
+		return nil, true, nil
+	case methodType.NumOut() == 1:
+		return method.Call(nil)[0].Interface(), true, nil
+	case methodType.NumOut() == 2 && methodType.Out(1) == errorType:
+		results := method.Call(nil)
+		if err, _ := results[1].Interface().(error); err != nil {
+			return nil, false, err
+		}
+		return results[0].Interface(), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// structField finds an exported struct field by its name or json tag.
+func structField(valRef reflect.Value, name string) (reflect.Value, bool) {
+	valType := valRef.Type()
+	for i := 0; i < valType.NumField(); i++ {
+		field := valType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Name == name || (tag != "" && tag == name) {
+			return valRef.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// compareValues compares two values and returns -1, 0, or 1.
+// Numbers and numeric strings are compared numerically, strings lexicographically, and
+// time.Time values chronologically, where the other side may be an RFC3339 string.
+// Comparing a number with a non-numeric string returns ErrIncomparableValues.
+func compareValues(a, b interface{}) (int, error) {
+	if at, bt, ok := toTimePair(a, b); ok {
+		return at.Compare(bt), nil
+	}
+	a, b = dereferenceIfNeeded(a), dereferenceIfNeeded(b)
+
+	af, aErr := toFloat64(a)
+	bf, bErr := toFloat64(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		switch {
+		case af < bf:
+			return -1, nil
+		case af > bf:
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	case isNumber(a) && bErr != nil, isNumber(b) && aErr != nil:
+		return 0, fmt.Errorf("%w: cannot compare %T with %T", ErrIncomparableValues, a, b)
+	}
+	return strings.Compare(toString(a), toString(b)), nil
+}
+
+// isNumber reports whether the value is of an integer or floating-point kind.
+func isNumber(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// toTimePair converts both values to time.Time when at least one of them already is one
+// and the other is either a time.Time or an RFC3339 string.
+func toTimePair(a, b interface{}) (time.Time, time.Time, bool) {
+	a, b = dereferenceIfNeeded(a), dereferenceIfNeeded(b)
+	_, aIsTime := a.(time.Time)
+	_, bIsTime := b.(time.Time)
+	if !aIsTime && !bIsTime {
+		return time.Time{}, time.Time{}, false
+	}
+	at, aOK := toTime(a)
+	bt, bOK := toTime(b)
+	return at, bt, aOK && bOK
+}
+
+// toTime converts a time.Time or an RFC3339 string into a time.Time.
+func toTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		return t, err == nil
+	default:
+		return time.Time{}, false
+	}
+}
+
+// isEmptyValue reports whether the value is nil, an empty string, or an empty collection.
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	valRef := reflect.ValueOf(value)
+	switch valRef.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return valRef.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return valRef.IsNil()
+	default:
+		return false
+	}
+}
@@ -1,7 +1,9 @@
 package template
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/test-go/testify/assert"
 )
@@ -52,3 +54,67 @@ func TestDereferenceIfNeeded(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareValuesWithTimes(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 1, 17, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		left     interface{}
+		right    interface{}
+		expected int
+	}{
+		{"EarlierTime", start, end, -1},
+		{"LaterTime", end, start, 1},
+		{"IdenticalTimes", start, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), 0},
+		{"SameInstantDifferentZones", start, start.In(time.FixedZone("CET", 3600)), 0},
+		{"TimeAgainstRFC3339String", start, "2024-03-01T12:00:00Z", -1},
+		{"RFC3339StringAgainstTime", "2024-03-01T12:00:00Z", start, 1},
+		{"TimePointer", &end, start, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := compareValues(tc.left, tc.right)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestCompareValuesOrdering(t *testing.T) {
+	cases := []struct {
+		name     string
+		left     interface{}
+		right    interface{}
+		expected int
+	}{
+		{"StringsLexicographic", "apple", "banana", -1},
+		{"StringsLexicographicReversed", "pear", "banana", 1},
+		{"EqualStrings", "kiwi", "kiwi", 0},
+		{"VersionLikeStrings", "v1.10", "v1.9", -1},
+		{"Numbers", 10, 9.5, 1},
+		{"NumberAgainstNumericString", 100, "99", 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := compareValues(tc.left, tc.right)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestCompareValuesMixedTypesError(t *testing.T) {
+	_, err := compareValues(42, "banana")
+	assert.True(t, errors.Is(err, ErrIncomparableValues), "Expected ErrIncomparableValues, got %v", err)
+
+	_, err = compareWithOperator("banana", "<", 42)
+	assert.True(t, errors.Is(err, ErrIncomparableValues), "Expected ErrIncomparableValues, got %v", err)
+
+	matched, err := compareWithOperator("banana", "!=", 42)
+	assert.NoError(t, err)
+	assert.True(t, matched)
+}